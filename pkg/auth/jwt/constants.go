@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2024 IOTech Ltd
+// Copyright (C) 2024-2026 IOTech Ltd
 //
 
 package jwt
@@ -13,8 +13,11 @@ const (
 	ClaimAccessId  = "access_id"
 	ClaimRefreshId = "refresh_id"
 	ClaimUsername  = "user_name"
-	ExpiresAt      = "exp"
-	Issuer         = "iss"
+	// ClaimName and ClaimSubject are the claims of the OpenBao-issued JWT identifying the caller
+	ClaimName    = "name"
+	ClaimSubject = "sub"
+	ExpiresAt    = "exp"
+	Issuer       = "iss"
 )
 
 // Constants related to Cookie and HTTP headers
@@ -26,6 +29,9 @@ const (
 
 	authorizationHeader = "Authorization"
 	bearer              = "Bearer"
+
+	// verifiedClaimsKey is the key of the verified claims in the echo context
+	verifiedClaimsKey = "verifiedJWTClaims"
 )
 
 // Constants related to error messages
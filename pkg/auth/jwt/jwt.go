@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2024-2025 IOTech Ltd
+// Copyright (C) 2024-2026 IOTech Ltd
 //
 
 package jwt
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
 )
@@ -26,6 +27,31 @@ func GetTokenStringFromRequest(r *http.Request) (string, errors.Error) {
 	return tokenString, nil
 }
 
+// SetVerifiedClaims stores the claims of the JWT verified by the authentication middleware in the echo context, so that
+// the subsequent middlewares and handlers of the request can trust them
+func SetVerifiedClaims(c echo.Context, claims jwt.MapClaims) {
+	c.Set(verifiedClaimsKey, claims)
+}
+
+// VerifiedClaimsFromContext gets the claims of the JWT verified by the authentication middleware from the echo context.
+// Unlike the claims parsed from the JWT of the request, they can be trusted as the caller identity. False is returned if
+// the request hasn't been authenticated, e.g. the route doesn't require authentication or the JWT validation is disabled.
+func VerifiedClaimsFromContext(c echo.Context) (jwt.MapClaims, bool) {
+	claims, ok := c.Get(verifiedClaimsKey).(jwt.MapClaims)
+	return claims, ok
+}
+
+// UsernameFromClaims gets the caller identity from the claims, which is the user_name claim of the IOTech-issued JWT,
+// or the name or subject claim of the OpenBao-issued JWT. An empty string is returned if none is found.
+func UsernameFromClaims(claims jwt.MapClaims) string {
+	for _, claim := range []string{ClaimUsername, ClaimName, ClaimSubject} {
+		if username, ok := claims[claim].(string); ok && username != "" {
+			return username
+		}
+	}
+	return ""
+}
+
 // CreateToken creates a new token with the given name and expiration time, specified in hours from now with the default expiration time of 2 hours for access token and 7 days for refresh token
 func CreateToken(name, secretKey, refreshSecretKey string, atExpiresFromNow, reExpiresFromNow *int64) (*TokenDetails, errors.Error) {
 	var err error
@@ -31,7 +31,7 @@ type baseOauth2Authenticator struct {
 func newBaseOauth2Authenticator(logger log.Logger) *baseOauth2Authenticator {
 	return &baseOauth2Authenticator{
 		tokens: make(map[string]*oauth2.Token),
-		lc:     logger,
+		lc:     log.Component(logger, logComponent),
 	}
 }
 
@@ -5,6 +5,9 @@
 package oauth2
 
 const (
+	// logComponent is the name of the component logger used by the OAuth2 authenticators
+	logComponent = "auth"
+
 	codeParam  = "code"
 	stateParam = "state"
 
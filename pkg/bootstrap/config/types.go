@@ -19,6 +19,7 @@ package config
 import (
 	"fmt"
 
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/secrets/client"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/secrets/types"
 )
@@ -32,10 +33,13 @@ const (
 )
 
 type GeneralConfiguration struct {
-	LogLevel        string
-	Service         ServiceInfo
-	SecretStore     SecretStoreInfo
-	InsecureSecrets InsecureSecrets
+	LogLevel           string
+	LogFormat          string
+	ComponentLogLevels map[string]string
+	LogFile            log.FileConfiguration
+	Service            ServiceInfo
+	SecretStore        SecretStoreInfo
+	InsecureSecrets    InsecureSecrets
 }
 
 // GetBootstrap returns the configuration elements required by the bootstrap.
@@ -50,6 +54,22 @@ func (c *GeneralConfiguration) GetLogLevel() string {
 	return c.LogLevel
 }
 
+// GetLogFormat returns the current ConfigurationStruct's log format.
+func (c *GeneralConfiguration) GetLogFormat() string {
+	return c.LogFormat
+}
+
+// GetComponentLogLevels returns the current ConfigurationStruct's log levels of the components, e.g. "sse", "secrets"
+// or "auth", which override the LogLevel for those components.
+func (c *GeneralConfiguration) GetComponentLogLevels() map[string]string {
+	return c.ComponentLogLevels
+}
+
+// GetLogFile returns the current ConfigurationStruct's file output configuration of the service log.
+func (c *GeneralConfiguration) GetLogFile() log.FileConfiguration {
+	return c.LogFile
+}
+
 // GetInsecureSecrets gets the config.InsecureSecrets field from the ConfigurationStruct.
 func (c *GeneralConfiguration) GetInsecureSecrets() InsecureSecrets {
 	return c.InsecureSecrets
@@ -95,8 +95,82 @@ func (cp *Processor) Process(
 
 	// Now that configuration has been loaded and overrides applied the log level can be set as configured.
 	err = cp.logger.SetLogLevel(serviceConfig.GetLogLevel())
+	if err != nil {
+		return err
+	}
+
+	// Only switch the log format when it is configured, otherwise keep the format the logger was created with.
+	if formatConfig, ok := serviceConfig.(interfaces.LogFormatConfiguration); ok {
+		if err = cp.setLogFormat(formatConfig.GetLogFormat()); err != nil {
+			return err
+		}
+	}
+
+	if fileConfig, ok := serviceConfig.(interfaces.LogFileConfiguration); ok {
+		if logFile := fileConfig.GetLogFile(); logFile.Enabled {
+			cp.setLogFileWriter(serviceType, logFile)
+		}
+	}
+
+	if levelsConfig, ok := serviceConfig.(interfaces.ComponentLogLevelsConfiguration); ok {
+		if componentLogger, ok := cp.logger.(log.ComponentLogger); ok {
+			if err = setComponentLogLevels(componentLogger, levelsConfig.GetComponentLogLevels()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setLogFormat switches the output format of the service log if the log format is configured. The configured format is
+// ignored with a warning if the logger doesn't support changing the format.
+func (cp *Processor) setLogFormat(logFormat string) error {
+	if logFormat == "" {
+		return nil
+	}
+
+	formatLogger, ok := cp.logger.(log.FormatLogger)
+	if !ok {
+		cp.logger.Warnf("the logger doesn't support changing the log format, ignore the log format `%s`", logFormat)
+		return nil
+	}
+	return formatLogger.SetLogFormat(logFormat)
+}
+
+// setComponentLogLevels sets the log levels of the components as configured. The log levels of the components which
+// are no longer configured are removed, so that these components fall back to the service log level.
+func setComponentLogLevels(componentLogger log.ComponentLogger, logLevels map[string]string) error {
+	for component := range componentLogger.ComponentLogLevels() {
+		if _, ok := logLevels[component]; !ok {
+			if err := componentLogger.SetComponentLogLevel(component, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	for component, logLevel := range logLevels {
+		if err := componentLogger.SetComponentLogLevel(component, logLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	return err
+// setLogFileWriter switches the service log to the rotated log file. The log keeps being written to STDOUT if the
+// log file can't be written.
+func (cp *Processor) setLogFileWriter(serviceKey string, logFile log.FileConfiguration) {
+	logWriter, err := log.NewFileWriter(serviceKey, logFile)
+	if err != nil {
+		cp.logger.Errorf("failed to set up the log file, keep writing the log to STDOUT: %v", err)
+		return
+	}
+
+	if err = log.SetLogWriter(cp.logger, logWriter); err != nil {
+		cp.logger.Warnf("failed to switch the log to the log file: %v", err)
+		return
+	}
+	cp.logger.Info("Service log is switched to the log file")
 }
 
 // LoadFromFile attempts to read and unmarshal toml-based configuration into a configuration struct.
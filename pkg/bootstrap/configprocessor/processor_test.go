@@ -0,0 +1,33 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+func TestSetComponentLogLevels(t *testing.T) {
+	componentLogger, ok := log.InitLogger("testService", log.InfoLog, nil).(log.ComponentLogger)
+	require.True(t, ok)
+
+	err := setComponentLogLevels(componentLogger, map[string]string{"sse": log.DebugLog, "auth": log.WarnLog})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sse": log.DebugLog, "auth": log.WarnLog}, componentLogger.ComponentLogLevels())
+
+	// the component removed from the configuration falls back to the service log level
+	err = setComponentLogLevels(componentLogger, map[string]string{"sse": log.TraceLog})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sse": log.TraceLog}, componentLogger.ComponentLogLevels())
+	assert.Equal(t, log.InfoLog, componentLogger.Component("auth").LogLevel())
+
+	err = setComponentLogLevels(componentLogger, map[string]string{"sse": "INF"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+)
+
+// AuditLoggerInterfaceName contains the name of the auditlog.Logger implementation in the DIC.
+var AuditLoggerInterfaceName = di.TypeInstanceToName((*auditlog.Logger)(nil))
+
+// AuditLoggerFrom helper function queries the DIC and returns the auditlog.Logger implementation.
+func AuditLoggerFrom(get di.Get) auditlog.Logger {
+	logger, ok := get(AuditLoggerInterfaceName).(auditlog.Logger)
+	if !ok {
+		return nil
+	}
+
+	return logger
+}
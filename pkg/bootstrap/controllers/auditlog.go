@@ -0,0 +1,91 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/utils"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+// MultiAuditLogEntriesResponse defines the response content of querying the audit log entries
+type MultiAuditLogEntriesResponse struct {
+	rest.BaseWithTotalCountResponse `json:",inline"`
+	Entries                         []auditlog.Entry `json:"entries"`
+}
+
+// NewMultiAuditLogEntriesResponse creates new MultiAuditLogEntriesResponse with all fields set appropriately
+func NewMultiAuditLogEntriesResponse(entries []auditlog.Entry, totalCount int64) MultiAuditLogEntriesResponse {
+	return MultiAuditLogEntriesResponse{
+		BaseWithTotalCountResponse: rest.NewBaseWithTotalCountResponse(common.ApiVersion, "", "", http.StatusOK, totalCount),
+		Entries:                    entries,
+	}
+}
+
+// AuditLogQueryHandler returns the handler to query the audit log entries with the given Reader, which can be
+// registered via CommonController.AddRoute, e.g. with common.ApiAuditLogRoute and the GET method.
+// The entries are filtered by the actor, action, severity, start and end query parameters, and paged by the offset
+// and limit query parameters. The newest entries are returned first.
+func AuditLogQueryHandler(dic *di.Container, reader *auditlog.Reader) echo.HandlerFunc {
+	return func(e echo.Context) error {
+		logger := container.LoggerFrom(dic.Get)
+		request := e.Request()
+		writer := e.Response()
+
+		maxResultCount := 0
+		if configuration := container.ConfigurationFrom(dic.Get); configuration != nil {
+			if service := configuration.GetBootstrap().Service; service != nil {
+				maxResultCount = service.MaxResultCount
+			}
+		}
+
+		offset, limit, _, err := rest.ParseGetAllObjectsRequestQueryString(request, 0, maxResultCount)
+		if err != nil {
+			return utils.SendJsonErrResp(logger, writer, request, errors.Kind(err), err.Error(), err, "")
+		}
+		// The offset and limit are only range-checked by ParseGetAllObjectsRequestQueryString with the max values set
+		if offset < 0 {
+			return utils.SendJsonErrResp(logger, writer, request, errors.KindContractInvalid, "offset must be greater than or equal to 0", nil, "")
+		}
+		if limit < -1 {
+			return utils.SendJsonErrResp(logger, writer, request, errors.KindContractInvalid, "limit must be greater than or equal to -1", nil, "")
+		}
+		start, end, err := rest.ParseStartEndRequestQueryString(request)
+		if err != nil {
+			return utils.SendJsonErrResp(logger, writer, request, errors.Kind(err), err.Error(), err, "")
+		}
+		if end < start {
+			return utils.SendJsonErrResp(logger, writer, request, errors.KindContractInvalid, "end must be greater than or equal to start", nil, "")
+		}
+
+		query := request.URL.Query()
+		filter := auditlog.Filter{
+			Actor:    query.Get(common.Actor),
+			Action:   auditlog.ActionType(strings.ToUpper(query.Get(common.Action))),
+			Severity: auditlog.Severity(strings.ToUpper(query.Get(common.Severity))),
+			Start:    time.UnixMilli(start),
+			End:      time.UnixMilli(end),
+		}
+
+		entries, totalCount, queryErr := reader.Query(filter, offset, limit)
+		if queryErr != nil {
+			return utils.SendJsonErrResp(logger, writer, request, errors.KindServerError, "failed to query the audit log entries", queryErr, "")
+		}
+
+		response := NewMultiAuditLogEntriesResponse(entries, totalCount)
+		return utils.SendJsonResp(logger, writer, request, response, http.StatusOK)
+	}
+}
@@ -0,0 +1,56 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+)
+
+func TestAuditLogQueryHandler(t *testing.T) {
+	config := auditlog.Configuration{StorageDir: t.TempDir(), Format: auditlog.JSONFormat}
+	auditLogger := auditlog.InitLogger(testServiceName, auditlog.BaseCoverage, nil, config)
+	auditLogger.SetEnabled(true)
+	auditLogger.LogBase(auditlog.SeverityNormal, "admin", auditlog.ActionTypeLogin, "admin logged in", nil)
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggerInterfaceName: func(get di.Get) any {
+			return log.InitLogger(testServiceName, log.InfoLog, io.Discard)
+		},
+	})
+	handler := AuditLogQueryHandler(dic, auditlog.NewReader(testServiceName, config))
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"all entries", "?limit=-1", http.StatusOK},
+		{"paging", "?offset=1&limit=1", http.StatusOK},
+		{"negative offset", "?offset=-1", http.StatusBadRequest},
+		{"negative offset without matching entries", "?offset=-1&actor=unknown", http.StatusBadRequest},
+		{"invalid limit", "?limit=-2", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/auditlog"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			require.NoError(t, handler(echo.New().NewContext(req, rec)))
+			assert.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+		})
+	}
+}
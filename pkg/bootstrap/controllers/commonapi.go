@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2023 IOTech Ltd
+// Copyright (C) 2023-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -15,12 +15,14 @@ import (
 
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/handlers"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/handlers/headers"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/interfaces"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/utils"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/models"
 )
 
@@ -50,6 +52,8 @@ func NewCommonController(dic *di.Container, r *echo.Echo, serviceName string, se
 	r.GET(common.ApiVersionRoute, c.Version, authenticationHook)
 	r.GET(common.ApiConfigRoute, c.Config, authenticationHook)
 	r.POST(common.ApiSecretRoute, c.AddSecret, authenticationHook)
+	r.GET(common.ApiLogLevelRoute, c.LogLevel, authenticationHook)
+	r.PUT(common.ApiLogLevelRoute, c.UpdateLogLevel, authenticationHook)
 
 	return &c
 }
@@ -127,6 +131,142 @@ func (c *CommonController) AddSecret(e echo.Context) error {
 	return utils.SendJsonResp(c.logger, writer, request, response, http.StatusCreated)
 }
 
+// LogLevel handles the request to the /loglevel endpoint. Is used to request the service's current log level settings
+// It returns a response as specified by the API swagger in the openapi directory
+func (c *CommonController) LogLevel(e echo.Context) error {
+	request := e.Request()
+	writer := e.Response()
+
+	var componentLogLevels map[string]string
+	if componentLogger, ok := c.logger.(log.ComponentLogger); ok {
+		componentLogLevels = componentLogger.ComponentLogLevels()
+	}
+
+	var auditLogSetting *models.AuditLogSetting
+	if reporter, ok := container.AuditLoggerFrom(c.dic.Get).(auditlog.SettingsReporter); ok {
+		enabled := reporter.Enabled()
+		auditLogSetting = &models.AuditLogSetting{
+			Enabled:       &enabled,
+			CoverageLevel: reporter.CoverageLevel(),
+		}
+	}
+
+	response := models.NewLogLevelResponse(c.serviceName, c.logger.LogLevel(), componentLogLevels, auditLogSetting)
+	return utils.SendJsonResp(c.logger, writer, request, response, http.StatusOK)
+}
+
+// UpdateLogLevel handles the request to the /loglevel endpoint. Is used to change the service's log level settings
+// at runtime without restarting the service. The change is audit-logged with the caller identity from the JWT verified
+// by the authentication middleware.
+// It returns a response as specified by the API swagger in the openapi directory
+func (c *CommonController) UpdateLogLevel(e echo.Context) error {
+	request := e.Request()
+	writer := e.Response()
+
+	defer func() {
+		_ = request.Body.Close()
+	}()
+
+	logLevelRequest := models.LogLevelRequest{}
+	err := json.NewDecoder(request.Body).Decode(&logLevelRequest)
+	if err != nil {
+		c.logger.Errorf("%v", err.Error())
+		return utils.SendJsonErrResp(c.logger, writer, request, errors.KindContractInvalid, "JSON decode failed", err, "")
+	}
+
+	auditLogger := container.AuditLoggerFrom(c.dic.Get)
+	err = updateLogLevel(c.logger, auditLogger, headers.ActorFromContext(e), logLevelRequest)
+	if err != nil {
+		return utils.SendJsonErrResp(c.logger, writer, request, errors.Kind(err), err.Error(), err, logLevelRequest.RequestId)
+	}
+
+	response := models.NewBaseResponse(logLevelRequest.RequestId, "", http.StatusOK)
+	return utils.SendJsonResp(c.logger, writer, request, response, http.StatusOK)
+}
+
+// updateLogLevel applies the log level settings of the request and audit-logs the change with the given actor.
+// All the settings are validated before any of them is applied, and the applied log levels are rolled back if one of
+// them fails, so that the settings are changed either all together or not at all.
+func updateLogLevel(logger log.Logger, auditLogger auditlog.Logger, actor string, request models.LogLevelRequest) errors.Error {
+	componentLogger, isComponentLogger := logger.(log.ComponentLogger)
+	if len(request.ComponentLogLevels) > 0 && !isComponentLogger {
+		return errors.NewBaseError(errors.KindServerError, "the service logger doesn't support component log levels", nil)
+	}
+	if request.AuditLog != nil && auditLogger == nil {
+		return errors.NewBaseError(errors.KindServerError, "audit logger is missing. Make sure it is added to the DIC", nil)
+	}
+	if err := request.Validate(); err != nil {
+		return errors.NewBaseError(errors.KindContractInvalid, "LogLevelRequest validation failed", err)
+	}
+
+	if err := applyLogLevels(logger, componentLogger, request); err != nil {
+		return err
+	}
+
+	details := auditlog.LogDetails{}
+	if request.LogLevel != "" {
+		details["logLevel"] = request.LogLevel
+	}
+	if len(request.ComponentLogLevels) > 0 {
+		details["componentLogLevels"] = request.ComponentLogLevels
+	}
+
+	var auditLogEnabled *bool
+	if request.AuditLog != nil {
+		if request.AuditLog.CoverageLevel != "" {
+			auditLogger.SetCoverageLevel(request.AuditLog.CoverageLevel)
+			details["auditLogCoverageLevel"] = request.AuditLog.CoverageLevel
+		}
+		auditLogEnabled = request.AuditLog.Enabled
+		if auditLogEnabled != nil {
+			details["auditLogEnabled"] = *auditLogEnabled
+			if *auditLogEnabled {
+				auditLogger.SetEnabled(true)
+			}
+		}
+	}
+
+	// Record the change before disabling the audit logger, otherwise disabling it would never be recorded
+	if auditLogger != nil && len(details) > 0 {
+		auditLogger.LogBase(auditlog.SeverityNormal, actor, auditlog.ActionTypeUpdate, "log level settings updated", details)
+	}
+	if auditLogEnabled != nil && !*auditLogEnabled {
+		auditLogger.SetEnabled(false)
+	}
+
+	return nil
+}
+
+// applyLogLevels sets the log level and the component log levels of the request, and restores the previous ones if
+// any of them fails to be set
+func applyLogLevels(logger log.Logger, componentLogger log.ComponentLogger, request models.LogLevelRequest) errors.Error {
+	previousLogLevel := logger.LogLevel()
+	var previousComponentLogLevels map[string]string
+	if componentLogger != nil {
+		previousComponentLogLevels = componentLogger.ComponentLogLevels()
+	}
+	rollback := func() {
+		_ = logger.SetLogLevel(previousLogLevel)
+		for component := range request.ComponentLogLevels {
+			// An empty log level removes the override which didn't exist before
+			_ = componentLogger.SetComponentLogLevel(component, previousComponentLogLevels[component])
+		}
+	}
+
+	if request.LogLevel != "" {
+		if err := logger.SetLogLevel(request.LogLevel); err != nil {
+			return errors.NewBaseError(errors.KindContractInvalid, "setting log level failed", err)
+		}
+	}
+	for component, logLevel := range request.ComponentLogLevels {
+		if err := componentLogger.SetComponentLogLevel(component, logLevel); err != nil {
+			rollback()
+			return errors.NewBaseError(errors.KindContractInvalid, "setting component log level failed", err)
+		}
+	}
+	return nil
+}
+
 // addSecret adds Service exclusive secret to the Secret Store
 func addSecret(dic *di.Container, request models.SecretRequest) errors.Error {
 	secretName, secret := prepareSecret(request)
@@ -0,0 +1,159 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/handlers/headers"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/models"
+)
+
+const testServiceName = "test-service"
+
+// failingComponentLogger is a ComponentLogger which fails to set the log level of the "broken" component
+type failingComponentLogger struct {
+	log.ComponentLogger
+}
+
+func (l failingComponentLogger) SetComponentLogLevel(name string, logLevel string) error {
+	if name == "broken" {
+		return errors.New("component log level not supported")
+	}
+	return l.ComponentLogger.SetComponentLogLevel(name, logLevel)
+}
+
+// minimalAuditLogger is an auditlog.Logger which doesn't implement auditlog.SettingsReporter
+type minimalAuditLogger struct {
+	auditlog.Logger
+}
+
+func newTestController(logger log.Logger, auditLogger auditlog.Logger) *CommonController {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.AuditLoggerInterfaceName: func(get di.Get) any {
+			return auditLogger
+		},
+	})
+	return &CommonController{dic: dic, serviceName: testServiceName, logger: logger}
+}
+
+func newTestAuditLogger(buf *bytes.Buffer) auditlog.Logger {
+	auditLogger := auditlog.InitLogger(testServiceName, auditlog.BaseCoverage, buf, auditlog.Configuration{Format: auditlog.JSONFormat})
+	auditLogger.SetEnabled(true)
+	return auditLogger
+}
+
+func serve(t *testing.T, handler echo.HandlerFunc, method string, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, "/api/v3/loglevel", reader)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler(echo.New().NewContext(req, rec)))
+	return rec
+}
+
+func TestLogLevel(t *testing.T) {
+	logger := log.InitLogger(testServiceName, log.InfoLog, io.Discard).(log.ComponentLogger)
+	require.NoError(t, logger.SetComponentLogLevel("sse", log.DebugLog))
+
+	controller := newTestController(logger, newTestAuditLogger(&bytes.Buffer{}))
+	rec := serve(t, controller.LogLevel, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp models.LogLevelResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, testServiceName, resp.ServiceName)
+	assert.Equal(t, log.InfoLog, resp.LogLevel)
+	assert.Equal(t, map[string]string{"sse": log.DebugLog}, resp.ComponentLogLevels)
+	require.NotNil(t, resp.AuditLog)
+	assert.True(t, *resp.AuditLog.Enabled)
+	assert.Equal(t, auditlog.BaseCoverage, resp.AuditLog.CoverageLevel)
+
+	// The audit log settings are omitted if the audit logger doesn't report them
+	controller = newTestController(logger, minimalAuditLogger{newTestAuditLogger(&bytes.Buffer{})})
+	rec = serve(t, controller.LogLevel, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	resp = models.LogLevelResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Nil(t, resp.AuditLog)
+}
+
+func TestUpdateLogLevel(t *testing.T) {
+	auditBuf := &bytes.Buffer{}
+	auditLogger := newTestAuditLogger(auditBuf)
+	logger := log.InitLogger(testServiceName, log.InfoLog, io.Discard).(log.ComponentLogger)
+	controller := newTestController(logger, auditLogger)
+
+	rec := serve(t, controller.UpdateLogLevel, http.MethodPut,
+		`{"apiVersion":"v3","logLevel":"DEBUG","componentLogLevels":{"sse":"TRACE"},"auditLog":{"coverageLevel":"FULL"}}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, log.DebugLog, logger.LogLevel())
+	assert.Equal(t, map[string]string{"sse": log.TraceLog}, logger.ComponentLogLevels())
+	assert.Equal(t, auditlog.FullCoverage, auditLogger.(auditlog.SettingsReporter).CoverageLevel())
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(auditBuf.Bytes(), &entry))
+	assert.Equal(t, headers.AnonymousActor, entry[auditlog.ActorKey])
+	assert.Equal(t, "log level settings updated", entry[auditlog.DescriptionKey])
+}
+
+func TestUpdateLogLevelInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid JSON", `{`},
+		{"invalid log level", `{"apiVersion":"v3","logLevel":"VERBOSE"}`},
+		{"invalid component log level", `{"apiVersion":"v3","logLevel":"DEBUG","componentLogLevels":{"sse":"VERBOSE"}}`},
+		{"invalid coverage level", `{"apiVersion":"v3","logLevel":"DEBUG","auditLog":{"coverageLevel":"ALL"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditBuf := &bytes.Buffer{}
+			logger := log.InitLogger(testServiceName, log.InfoLog, io.Discard)
+			controller := newTestController(logger, newTestAuditLogger(auditBuf))
+
+			rec := serve(t, controller.UpdateLogLevel, http.MethodPut, tt.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, log.InfoLog, logger.LogLevel())
+			assert.Empty(t, auditBuf.String())
+		})
+	}
+}
+
+func TestUpdateLogLevelRollback(t *testing.T) {
+	auditBuf := &bytes.Buffer{}
+	componentLogger := log.InitLogger(testServiceName, log.InfoLog, io.Discard).(log.ComponentLogger)
+	require.NoError(t, componentLogger.SetComponentLogLevel("sse", log.WarnLog))
+	logger := failingComponentLogger{componentLogger}
+	controller := newTestController(logger, newTestAuditLogger(auditBuf))
+
+	rec := serve(t, controller.UpdateLogLevel, http.MethodPut,
+		`{"apiVersion":"v3","logLevel":"DEBUG","componentLogLevels":{"sse":"TRACE","bus":"TRACE","broken":"TRACE"}}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// None of the log levels is changed, and nothing is audit-logged
+	assert.Equal(t, log.InfoLog, logger.LogLevel())
+	assert.Equal(t, map[string]string{"sse": log.WarnLog}, logger.ComponentLogLevels())
+	assert.Empty(t, auditBuf.String())
+}
@@ -0,0 +1,168 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/handlers/headers"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+// Keys of the LogDetails recorded by AuditLogMiddleware
+const (
+	AuditDetailMethod        = "method"
+	AuditDetailPath          = "path"
+	AuditDetailStatusCode    = "statusCode"
+	AuditDetailCorrelationID = "correlationId"
+)
+
+// AuditRouteConfig defines how the requests to a route are audit-logged by AuditLogMiddleware
+type AuditRouteConfig struct {
+	// Disabled opts the route out of the audit log.
+	Disabled bool
+	// CoverageLevel is the coverage level of the audit log entries, which is one of BASE, ADVANCED and FULL. It
+	// defaults to FULL for the GET, HEAD and OPTIONS requests, and BASE for the others.
+	CoverageLevel string
+	// Severity is the severity of the audit log entries. It defaults to NORMAL.
+	Severity auditlog.Severity
+	// Action overrides the action type mapped from the HTTP method.
+	Action auditlog.ActionType
+	// Description overrides the description of the audit log entries, which defaults to the method and the route.
+	Description string
+}
+
+// AuditRoute declares the AuditRouteConfig of a route for AuditLogMiddleware. The route is identified by the HTTP
+// method and the path as registered in echo, e.g. http.MethodDelete and "/api/v3/device/:name".
+type AuditRoute struct {
+	Method string
+	Path   string
+	Config AuditRouteConfig
+}
+
+// AuditLogMiddleware returns a middleware which audit-logs each request with the audit logger in the DIC once the
+// request is handled. The action type is mapped from the HTTP method, the actor is taken from the JWT verified by the
+// authentication middleware of the route, and the method, path, status code and correlation ID are recorded in the
+// LogDetails. The given routes can declare their coverage level and severity, override the description or opt out.
+// The route configs are resolved before the request is handled, so that they also apply to the requests rejected by
+// the route-level middlewares, e.g. the authentication. Usage:
+//
+//	e.Use(handlers.AuditLogMiddleware(dic,
+//		handlers.AuditRoute{Method: http.MethodDelete, Path: "/api/v3/device/:name",
+//			Config: handlers.AuditRouteConfig{Severity: auditlog.SeverityCritical}}))
+//
+// The requests are not audit-logged if there is no audit logger in the DIC.
+func AuditLogMiddleware(dic *di.Container, routes ...AuditRoute) echo.MiddlewareFunc {
+	configs := make(map[string]AuditRouteConfig, len(routes))
+	for _, route := range routes {
+		configs[route.Method+" "+route.Path] = route.Config
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			// The route is matched by the router before the middlewares registered by echo.Use are executed
+			config := configs[r.Method+" "+c.Path()]
+
+			err := next(c)
+
+			auditLogger := container.AuditLoggerFrom(dic.Get)
+			if auditLogger == nil {
+				return err
+			}
+			if reporter, ok := auditLogger.(auditlog.SettingsReporter); ok && !reporter.Enabled() {
+				return err
+			}
+			if config.Disabled {
+				return err
+			}
+
+			statusCode := c.Response().Status
+			if err != nil {
+				// The error is written to the response by the echo error handler after the middleware returns
+				statusCode = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					statusCode = httpErr.Code
+				}
+			}
+
+			// Only the JWT verified by the authentication middleware is trusted as the caller identity
+			actor := headers.ActorFromContext(c)
+
+			correlationID := rest.FromContext(r.Context(), common.CorrelationID)
+			if correlationID == "" {
+				correlationID = r.Header.Get(common.CorrelationID)
+			}
+
+			action := config.Action
+			if action == "" {
+				action = actionTypeFromMethod(r.Method)
+			}
+			severity := config.Severity
+			if severity == "" {
+				severity = auditlog.SeverityNormal
+			}
+			description := config.Description
+			if description == "" {
+				description = r.Method + " " + c.Path()
+			}
+			details := auditlog.LogDetails{
+				AuditDetailMethod:        r.Method,
+				AuditDetailPath:          r.URL.Path,
+				AuditDetailStatusCode:    statusCode,
+				AuditDetailCorrelationID: correlationID,
+			}
+
+			switch coverageLevel(config.CoverageLevel, r.Method) {
+			case auditlog.FullCoverage:
+				auditLogger.LogFull(severity, actor, action, description, details)
+			case auditlog.AdvancedCoverage:
+				auditLogger.LogAdvanced(severity, actor, action, description, details)
+			default:
+				auditLogger.LogBase(severity, actor, action, description, details)
+			}
+
+			return err
+		}
+	}
+}
+
+// actionTypeFromMethod maps the HTTP method to the audit log action type
+func actionTypeFromMethod(method string) auditlog.ActionType {
+	switch method {
+	case http.MethodPost:
+		return auditlog.ActionTypeCreate
+	case http.MethodPut, http.MethodPatch:
+		return auditlog.ActionTypeUpdate
+	case http.MethodDelete:
+		return auditlog.ActionTypeDelete
+	case http.MethodGet, http.MethodHead:
+		return auditlog.ActionTypeRead
+	default:
+		return auditlog.ActionTypeUnknown
+	}
+}
+
+// coverageLevel returns the coverage level declared by the route, or the default coverage level of the HTTP method
+func coverageLevel(declared string, method string) string {
+	if declared != "" {
+		return declared
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return auditlog.FullCoverage
+	default:
+		return auditlog.BaseCoverage
+	}
+}
@@ -0,0 +1,139 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authJWT "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/auth/jwt"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/handlers/headers"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+)
+
+// testSigningKey is the key of the JWT verified by testAuthentication
+var testSigningKey = []byte("key")
+
+// testAuthentication verifies the JWT of the request with testSigningKey like the authentication middleware
+func testAuthentication(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims := jwt.MapClaims{}
+		token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if _, err := jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (any, error) {
+			return testSigningKey, nil
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, err)
+		}
+		authJWT.SetVerifiedClaims(c, claims)
+		return next(c)
+	}
+}
+
+func TestAuditLogMiddleware(t *testing.T) {
+	buf := &bytes.Buffer{}
+	auditLogger := auditlog.InitLogger("test-service", auditlog.AdvancedCoverage, buf, auditlog.Configuration{Format: auditlog.JSONFormat})
+	auditLogger.SetEnabled(true)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.AuditLoggerInterfaceName: func(get di.Get) any {
+			return auditLogger
+		},
+	})
+
+	e := echo.New()
+	e.Use(AuditLogMiddleware(dic,
+		AuditRoute{Method: http.MethodGet, Path: "/device/:name", Config: AuditRouteConfig{CoverageLevel: auditlog.BaseCoverage, Description: "device queried"}},
+		AuditRoute{Method: http.MethodDelete, Path: "/device/:name", Config: AuditRouteConfig{Severity: auditlog.SeverityCritical}},
+		AuditRoute{Method: http.MethodGet, Path: "/ping", Config: AuditRouteConfig{Disabled: true}},
+		AuditRoute{Method: http.MethodGet, Path: "/secret", Config: AuditRouteConfig{Disabled: true}},
+	))
+	e.POST("/device", handler, testAuthentication)
+	e.GET("/device", handler, testAuthentication)
+	e.GET("/device/:name", handler)
+	e.DELETE("/device/:name", handler, testAuthentication)
+	e.PUT("/device", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	})
+	e.GET("/ping", handler)
+	e.GET("/secret", handler, testAuthentication)
+
+	validToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{authJWT.ClaimUsername: "alice"}).SignedString(testSigningKey)
+	require.NoError(t, err)
+	forgedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{authJWT.ClaimUsername: "mallory"}).SignedString([]byte("forged"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		method              string
+		path                string
+		token               string
+		expectedLogged      bool
+		expectedLevel       string
+		expectedSeverity    auditlog.Severity
+		expectedActor       string
+		expectedAction      auditlog.ActionType
+		expectedDescription string
+		expectedStatusCode  int
+	}{
+		{"create", http.MethodPost, "/device", validToken, true, auditlog.BaseCoverage, auditlog.SeverityNormal, "alice", auditlog.ActionTypeCreate, "POST /device", http.StatusOK},
+		{"read with full coverage by default", http.MethodGet, "/device", validToken, false, "", "", "", "", "", 0},
+		{"read with declared coverage and description", http.MethodGet, "/device/d1", validToken, true, auditlog.BaseCoverage, auditlog.SeverityNormal, headers.AnonymousActor, auditlog.ActionTypeRead, "device queried", http.StatusOK},
+		{"delete with declared severity", http.MethodDelete, "/device/d1", validToken, true, auditlog.BaseCoverage, auditlog.SeverityCritical, "alice", auditlog.ActionTypeDelete, "DELETE /device/:name", http.StatusOK},
+		{"rejected by authentication", http.MethodDelete, "/device/d1", forgedToken, true, auditlog.BaseCoverage, auditlog.SeverityCritical, headers.AnonymousActor, auditlog.ActionTypeDelete, "DELETE /device/:name", http.StatusUnauthorized},
+		{"unauthorized", http.MethodPut, "/device", validToken, true, auditlog.BaseCoverage, auditlog.SeverityNormal, headers.AnonymousActor, auditlog.ActionTypeUpdate, "PUT /device", http.StatusUnauthorized},
+		{"opt out", http.MethodGet, "/ping", validToken, false, "", "", "", "", "", 0},
+		{"opt out rejected by authentication", http.MethodGet, "/secret", forgedToken, false, "", "", "", "", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			req.Header.Set(common.CorrelationID, expectedCorrelationId)
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.expectedLogged {
+				assert.Empty(t, buf.String())
+				return
+			}
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &entry))
+			assert.Equal(t, tt.expectedLevel, entry["level"])
+			assert.Equal(t, string(tt.expectedSeverity), entry[auditlog.SeverityKey])
+			assert.Equal(t, tt.expectedActor, entry[auditlog.ActorKey])
+			assert.Equal(t, string(tt.expectedAction), entry[auditlog.ActionKey])
+			assert.Equal(t, tt.expectedDescription, entry[auditlog.DescriptionKey])
+			assert.Equal(t, map[string]any{
+				AuditDetailMethod:        tt.method,
+				AuditDetailPath:          tt.path,
+				AuditDetailStatusCode:    float64(tt.expectedStatusCode),
+				AuditDetailCorrelationID: expectedCorrelationId,
+			}, entry[auditlog.DetailsKey])
+		})
+	}
+}
+
+func TestAuditLogMiddlewareWithoutAuditLogger(t *testing.T) {
+	e := echo.New()
+	e.Use(AuditLogMiddleware(di.NewContainer(di.ServiceConstructorMap{})))
+	e.GET("/", handler)
+
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+}
@@ -1,6 +1,6 @@
 /*******************************************************************************
  * Copyright 2023 Intel Corporation
- * Copyright 2023-2025 IOTech Ltd
+ * Copyright 2023-2026 IOTech Ltd
  *
  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
  * in compliance with the License. You may obtain a copy of the License at
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	authJWT "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/auth/jwt"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/handlers/headers"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/secret"
@@ -36,7 +37,8 @@ import (
 const openBaoIssuer = "/v1/identity/oidc"
 
 // AuthenticationHandlerFunc prefixes an existing HandlerFunc,
-// performing authentication checks based on OpenBao-issued JWTs or external JWTs by checking the Authorization header. Usage:
+// performing authentication checks based on OpenBao-issued JWTs or external JWTs by checking the Authorization header.
+// The claims of the verified JWT are stored in the echo context, see jwt.VerifiedClaimsFromContext. Usage:
 //
 // authenticationHook := handlers.NilAuthenticationHandlerFunc()
 //
@@ -87,6 +89,10 @@ func AuthenticationHandlerFunc(dic *di.Container) echo.MiddlewareFunc {
 				if err != nil {
 					return echo.NewHTTPError(http.StatusUnauthorized, err)
 				} else {
+					// The claims are parsed from the token which has just been verified
+					if claims, ok := parsedToken.Claims.(*jwt.MapClaims); ok {
+						authJWT.SetVerifiedClaims(c, *claims)
+					}
 					return next(c)
 				}
 			}
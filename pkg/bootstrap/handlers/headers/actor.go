@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package headers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+
+	authJWT "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/auth/jwt"
+)
+
+// AnonymousActor is the actor used when the caller identity can't be extracted from the request
+const AnonymousActor = "anonymous"
+
+// ActorFromContext returns the caller identity from the claims of the JWT verified by the authentication middleware,
+// which is the user_name claim of the IOTech-issued JWT, or the name or subject claim of the OpenBao-issued JWT.
+// AnonymousActor is returned if the request hasn't been authenticated or the caller identity can't be found.
+func ActorFromContext(c echo.Context) string {
+	claims, ok := authJWT.VerifiedClaimsFromContext(c)
+	if !ok {
+		return AnonymousActor
+	}
+	if actor := authJWT.UsernameFromClaims(claims); actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// ActorFromRequest returns the caller identity from the JWT in the Authorization header of the request in the same way
+// as ActorFromContext, except that the JWT is parsed without verification. It must not be trusted unless the JWT has
+// been validated by the caller; use ActorFromContext to get the identity verified by the authentication middleware.
+// AnonymousActor is returned if the request doesn't carry a JWT or the caller identity can't be found.
+func ActorFromRequest(r *http.Request) string {
+	authParts := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(authParts) < 2 || !strings.EqualFold(authParts[0], "Bearer") {
+		return AnonymousActor
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(authParts[1], claims); err != nil {
+		return AnonymousActor
+	}
+	if actor := authJWT.UsernameFromClaims(claims); actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
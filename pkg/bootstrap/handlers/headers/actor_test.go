@@ -0,0 +1,68 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package headers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authJWT "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/auth/jwt"
+)
+
+func signedToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("key"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestActorFromRequest(t *testing.T) {
+	aliceToken := signedToken(t, jwt.MapClaims{authJWT.ClaimUsername: "alice"})
+
+	tests := []struct {
+		name     string
+		header   string
+		cookie   string
+		expected string
+	}{
+		{"user_name claim", "Bearer " + aliceToken, "", "alice"},
+		{"subject claim", "Bearer " + signedToken(t, jwt.MapClaims{authJWT.ClaimSubject: "bob"}), "", "bob"},
+		{"no caller identity", "Bearer " + signedToken(t, jwt.MapClaims{}), "", AnonymousActor},
+		{"no JWT", "", "", AnonymousActor},
+		{"not a bearer token", "Basic " + aliceToken, "", AnonymousActor},
+		{"invalid JWT", "Bearer invalid", "", AnonymousActor},
+		{"access token cookie is ignored", "", aliceToken, AnonymousActor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: authJWT.AccessTokenCookie, Value: tt.cookie})
+			}
+			assert.Equal(t, tt.expected, ActorFromRequest(req))
+		})
+	}
+}
+
+func TestActorFromContext(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+signedToken(t, jwt.MapClaims{authJWT.ClaimUsername: "mallory"}))
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	// The unverified JWT of the request isn't trusted
+	assert.Equal(t, AnonymousActor, ActorFromContext(c))
+
+	authJWT.SetVerifiedClaims(c, jwt.MapClaims{authJWT.ClaimUsername: "alice"})
+	assert.Equal(t, "alice", ActorFromContext(c))
+}
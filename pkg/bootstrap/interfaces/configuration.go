@@ -16,7 +16,10 @@
 
 package interfaces
 
-import "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/config"
+import (
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/config"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
 
 // Configuration interface provides an abstraction around a configuration struct.
 type Configuration interface {
@@ -30,3 +33,24 @@ type Configuration interface {
 	// GetInsecureSecrets gets the config.InsecureSecrets field from the configuration struct.
 	GetInsecureSecrets() config.InsecureSecrets
 }
+
+// LogFormatConfiguration is an optional interface of Configuration providing the output format of the service log.
+// The callers should check for it with a type assertion.
+type LogFormatConfiguration interface {
+	// GetLogFormat returns the current ConfigurationStruct's log format.
+	GetLogFormat() string
+}
+
+// ComponentLogLevelsConfiguration is an optional interface of Configuration providing the log levels of the
+// components. The callers should check for it with a type assertion.
+type ComponentLogLevelsConfiguration interface {
+	// GetComponentLogLevels returns the current ConfigurationStruct's log levels of the components.
+	GetComponentLogLevels() map[string]string
+}
+
+// LogFileConfiguration is an optional interface of Configuration providing the file output configuration of the
+// service log. The callers should check for it with a type assertion.
+type LogFileConfiguration interface {
+	// GetLogFile returns the current ConfigurationStruct's file output configuration of the service log.
+	GetLogFile() log.FileConfiguration
+}
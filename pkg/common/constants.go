@@ -14,19 +14,24 @@ const (
 	ApiVersion = "v1"
 	ApiBase    = "/api/v1"
 
-	ApiConfigRoute  = ApiBase + "/config"
-	ApiPingRoute    = ApiBase + "/ping"
-	ApiVersionRoute = ApiBase + "/version"
-	ApiSecretRoute  = ApiBase + "/secret"
+	ApiConfigRoute   = ApiBase + "/config"
+	ApiPingRoute     = ApiBase + "/ping"
+	ApiVersionRoute  = ApiBase + "/version"
+	ApiSecretRoute   = ApiBase + "/secret"
+	ApiLogLevelRoute = ApiBase + "/loglevel"
+	ApiAuditLogRoute = ApiBase + "/auditlog"
 )
 
 // constants relate to the url query parameters
 const (
+	Action         = "action"
+	Actor          = "actor"
 	CommaSeparator = ","
 	End            = "end"
 	Limit          = "limit"
 	Labels         = "labels"
 	Offset         = "offset"
+	Severity       = "severity"
 	Since          = "since"
 	Start          = "start"
 	Tail           = "tail"
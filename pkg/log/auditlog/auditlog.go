@@ -6,7 +6,9 @@ package auditlog
 
 import (
 	"context"
+	"fmt"
 	"io"
+	stdLog "log"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -24,14 +26,20 @@ const (
 	DescriptionKey = "desc"
 	DetailsKey     = "details"
 	SeverityKey    = "severity"
+	SequenceKey    = "seq"
+	HashKey        = "hash"
 )
 
 type logger struct {
 	enabled           bool
 	owningServiceName string
 	coverageLevel     *slog.LevelVar
-	logger            *slog.Logger
+	writer            io.Writer
+	jsonFormat        bool
+	handlerOptions    *slog.HandlerOptions
 	mu                sync.RWMutex
+	// writeMu serializes the entries written by the handlers, which are created for each entry
+	writeMu sync.Mutex
 }
 
 //// Entry builds up an audit log entry with the following fields
@@ -59,34 +67,66 @@ func InitLogger(owningServiceName string, coverageLevel string, logWriter io.Wri
 	l.SetCoverageLevel(coverageLevel)
 
 	// Set up a default log writer if it is not provided
+	filePath := ""
 	if logWriter == nil {
 		config.setDefault()
-		// Add the service name to the log file name as a prefix
-		fileName := owningServiceName + "-" + config.FileName
-		if canCreateFileInDir(config.StorageDir, fileName) {
-			// Set up the file writer and log rotation configuration
-			logWriter = &lumberjack.Logger{
-				Filename:   config.StorageDir + "/" + fileName,
-				MaxSize:    config.MaxSize, // megabytes
-				MaxBackups: config.MaxBackups,
-				MaxAge:     config.MaxAge, //days
-				LocalTime:  true,
-				Compress:   true, // disabled by default
-			}
+		if fileWriter, err := NewFileWriter(owningServiceName, config); err == nil {
+			filePath = logFilePath(owningServiceName, config)
+			logWriter = fileWriter
 		} else {
 			logWriter = os.Stdout
 		}
 	}
 
-	// Set up the logger
-	l.logger = slog.New(slog.NewTextHandler(logWriter, &slog.HandlerOptions{
+	// Chain the entries with their hashes, continuing from the last entry in the audit log files if any. The chain
+	// starts over if it can't be resumed, which is reported as a gap by VerifyHashChain.
+	if config.HashChain {
+		chain := newChainWriter(logWriter, config.HMACKey)
+		if filePath != "" {
+			files, err := logFiles(filePath)
+			if err == nil {
+				err = chain.resume(files)
+			}
+			if err != nil {
+				stdLog.Printf("failed to resume the audit log hash chain from %s, the chain starts over: %v", filePath, err)
+			}
+		}
+		logWriter = chain
+	}
+
+	// Set up the handler options of the configured format
+	l.writer = logWriter
+	l.jsonFormat = strings.EqualFold(config.Format, JSONFormat)
+	l.handlerOptions = &slog.HandlerOptions{
 		Level:       l.coverageLevel,
 		ReplaceAttr: replaceAttr,
-	}))
+	}
 
 	return &l
 }
 
+// NewFileWriter creates the rotated audit log file writer according to the given configuration, which is the default
+// log writer of InitLogger. It can be used to write the audit log to file along with other log writers. An error is
+// returned if the audit log file can't be created.
+func NewFileWriter(owningServiceName string, config Configuration) (io.Writer, error) {
+	config.setDefault()
+	// Add the service name to the log file name as a prefix
+	fileName := owningServiceName + "-" + config.FileName
+	if !canCreateFileInDir(config.StorageDir, fileName) {
+		return nil, fmt.Errorf("audit log file %s can't be created in %s", fileName, config.StorageDir)
+	}
+
+	// Set up the file writer and log rotation configuration
+	return &lumberjack.Logger{
+		Filename:   logFilePath(owningServiceName, config),
+		MaxSize:    config.MaxSize, // megabytes
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAge, //days
+		LocalTime:  true,
+		Compress:   true, // disabled by default
+	}, nil
+}
+
 // SetEnabled sets the enabled status for the logger
 func (l *logger) SetEnabled(enabled bool) {
 	l.mu.Lock()
@@ -95,16 +135,31 @@ func (l *logger) SetEnabled(enabled bool) {
 	l.enabled = enabled
 }
 
+// Enabled returns the enabled status of the logger
+func (l *logger) Enabled() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.enabled
+}
+
 // SetCoverageLevel sets the coverage level for the logger
 func (l *logger) SetCoverageLevel(coverageLevel string) {
 	// Use BASE coverage level if the given coverage level is invalid
 	if !isValidCoverageLevel(coverageLevel) {
 		coverageLevel = BaseCoverage
 	}
-	// Set up the coverage level for this program
-	var programLevel = new(slog.LevelVar)
-	programLevel.Set(slogLevelFromString(coverageLevel))
-	l.coverageLevel = programLevel
+	// Set up the coverage level for this program. The LevelVar is reused once created, so that the change takes
+	// effect on the handler which has been set up with it.
+	if l.coverageLevel == nil {
+		l.coverageLevel = new(slog.LevelVar)
+	}
+	l.coverageLevel.Set(slogLevelFromString(coverageLevel))
+}
+
+// CoverageLevel returns the current coverage level setting
+func (l *logger) CoverageLevel() string {
+	return coverageLevelFromSlogLevel(l.coverageLevel.Level())
 }
 
 // LogBase adds an audit log entry to the log writer with base coverage level
@@ -155,7 +210,18 @@ func (l *logger) log(coverageLevel slog.Level, severity Severity, actor string,
 		attrs = append(attrs, slog.Any(DetailsKey, details))
 	}
 
-	l.logger.LogAttrs(
+	// The handler is created for each entry to pass the severity to the log writer
+	writer := severityEntryWriter{writer: l.writer, severity: severity}
+	var handler slog.Handler
+	if l.jsonFormat {
+		handler = slog.NewJSONHandler(writer, l.handlerOptions)
+	} else {
+		handler = slog.NewTextHandler(writer, l.handlerOptions)
+	}
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	slog.New(handler).LogAttrs(
 		context.Background(),
 		coverageLevel,
 		"", // omit the message as it is not used in the audit log
@@ -163,6 +229,24 @@ func (l *logger) log(coverageLevel slog.Level, severity Severity, actor string,
 	)
 }
 
+// severityEntryWriter writes a single audit log entry of the given severity
+type severityEntryWriter struct {
+	writer   io.Writer
+	severity Severity
+}
+
+func (w severityEntryWriter) Write(p []byte) (int, error) {
+	return writeSeverity(w.writer, w.severity, p)
+}
+
+// writeSeverity writes the audit log entry with its severity if the writer is a SeverityWriter
+func writeSeverity(writer io.Writer, severity Severity, p []byte) (int, error) {
+	if sw, ok := writer.(SeverityWriter); ok {
+		return sw.WriteSeverity(severity, p)
+	}
+	return writer.Write(p)
+}
+
 // canCreateFileInDir is a helper function to check if a file can be created in the given directory
 func canCreateFileInDir(dirPath string, fileName string) bool {
 	// Check if the file exists
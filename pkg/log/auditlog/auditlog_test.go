@@ -0,0 +1,66 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLogger(testServiceName, FullCoverage, buf, Configuration{Format: JSONFormat})
+	logger.SetEnabled(true)
+	logger.LogBase(SeverityCritical, "admin", ActionTypeDelete, "device deleted", LogDetails{"name": "device1", "count": 2})
+	logger.LogFull(SeverityMinor, "admin", ActionTypeRead, "device read", nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.NotEmpty(t, entry[TimestampKey])
+	assert.Equal(t, testServiceName, entry[appKey])
+	assert.Equal(t, BaseCoverage, entry["level"])
+	assert.Equal(t, string(SeverityCritical), entry[SeverityKey])
+	assert.Equal(t, "admin", entry[ActorKey])
+	assert.Equal(t, string(ActionTypeDelete), entry[ActionKey])
+	assert.Equal(t, "device deleted", entry[DescriptionKey])
+	assert.Equal(t, map[string]any{"name": "device1", "count": float64(2)}, entry[DetailsKey])
+
+	entry = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, FullCoverage, entry["level"])
+	assert.NotContains(t, entry, DetailsKey)
+	assert.NotContains(t, entry, "msg")
+}
+
+func TestJSONFormatHashChain(t *testing.T) {
+	config := Configuration{StorageDir: t.TempDir(), Format: JSONFormat, HashChain: true}
+	logger := InitLogger(testServiceName, BaseCoverage, nil, config)
+	logger.SetEnabled(true)
+	logger.LogBase(SeverityNormal, "admin", ActionTypeLogin, "user logged in", nil)
+	logger.LogBase(SeverityNormal, "admin", ActionTypeLogout, "user logged out", nil)
+
+	report, err := VerifyHashChain(testServiceName, config)
+	require.NoError(t, err)
+	assert.True(t, report.Valid(), report.Issues)
+	assert.Equal(t, 2, report.Entries)
+}
+
+func TestTextFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLogger(testServiceName, BaseCoverage, buf, Configuration{})
+	logger.SetEnabled(true)
+	logger.LogBase(SeverityNormal, "admin", ActionTypeLogin, "user logged in", nil)
+
+	assert.True(t, strings.HasPrefix(buf.String(), "level=BASE ts="), buf.String())
+	assert.Contains(t, buf.String(), `severity=NORMAL actor=admin action=LOGIN desc="user logged in"`)
+}
@@ -0,0 +1,291 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Constants of the kinds of issues reported by VerifyHashChain
+const (
+	// ChainIssueGap indicates that entries are missing before the entry
+	ChainIssueGap = "GAP"
+	// ChainIssueModified indicates that the entry doesn't match its hash
+	ChainIssueModified = "MODIFIED"
+	// ChainIssueMalformed indicates that the entry doesn't carry a sequence number and a hash
+	ChainIssueMalformed = "MALFORMED"
+)
+
+// maxEntrySize is the maximum size of an audit log entry read from the log files
+const maxEntrySize = 4 * 1024 * 1024
+
+var (
+	textSequenceSep = []byte(" " + SequenceKey + "=")
+	textHashSep     = []byte(" " + HashKey + "=")
+	jsonSequenceSep = []byte(`,"` + SequenceKey + `":`)
+	jsonHashSep     = []byte(`,"` + HashKey + `":"`)
+)
+
+// SecretProvider defines the subset of the secret provider APIs used to retrieve the HMAC key of the hash chain,
+// which is satisfied by the SecretProvider of the bootstrap package.
+type SecretProvider interface {
+	GetSecret(secretName string, keys ...string) (map[string]string, error)
+}
+
+// HMACKeyFromSecretProvider retrieves the HMAC key of the hash chain from the secret store
+func HMACKeyFromSecretProvider(provider SecretProvider, secretName string, secretKey string) ([]byte, error) {
+	secrets, err := provider.GetSecret(secretName, secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the audit log HMAC key from secret %s: %w", secretName, err)
+	}
+	key := secrets[secretKey]
+	if key == "" {
+		return nil, fmt.Errorf("audit log HMAC key %s is empty in secret %s", secretKey, secretName)
+	}
+	return []byte(key), nil
+}
+
+// ChainIssue describes an issue found in the hash chain of the audit log entries
+type ChainIssue struct {
+	File    string
+	Line    int
+	Seq     uint64
+	Kind    string
+	Message string
+}
+
+// ChainReport is the result of verifying the hash chain of the audit log entries
+type ChainReport struct {
+	Files    []string
+	Entries  int
+	FirstSeq uint64
+	LastSeq  uint64
+	Issues   []ChainIssue
+}
+
+// Valid returns whether no issue is found in the hash chain
+func (r ChainReport) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// VerifyHashChain walks the rotated and current audit log files of the service in chronological order and reports
+// the gaps and modifications of the hash chain. The config should be the same as the one passed to InitLogger,
+// including the HMACKey. If the oldest files have been removed by rotation, the chain is anchored on the first
+// remaining entry.
+func VerifyHashChain(owningServiceName string, config Configuration) (ChainReport, error) {
+	config.setDefault()
+
+	report := ChainReport{}
+	files, err := logFiles(logFilePath(owningServiceName, config))
+	if err != nil {
+		return report, fmt.Errorf("failed to list the audit log files: %w", err)
+	}
+	report.Files = files
+
+	var prevSeq uint64
+	var prevHash string
+	started := false
+	for _, file := range files {
+		err := readLogFile(file, func(lineNo int, line []byte) {
+			content, seq, entryHash, ok := parseChainEntry(line)
+			if !ok {
+				report.Issues = append(report.Issues, ChainIssue{File: file, Line: lineNo, Kind: ChainIssueMalformed,
+					Message: "entry doesn't carry a sequence number and a hash"})
+				return
+			}
+			report.Entries++
+
+			switch {
+			case !started:
+				started = true
+				report.FirstSeq = seq
+				// The chain can only be verified from the very first entry, otherwise it is anchored on this entry
+				if seq == 1 && computeHash(config.HMACKey, "", content) != entryHash {
+					report.Issues = append(report.Issues, ChainIssue{File: file, Line: lineNo, Seq: seq, Kind: ChainIssueModified,
+						Message: "entry doesn't match its hash"})
+				}
+			case seq != prevSeq+1:
+				report.Issues = append(report.Issues, ChainIssue{File: file, Line: lineNo, Seq: seq, Kind: ChainIssueGap,
+					Message: fmt.Sprintf("expected sequence %d but found %d", prevSeq+1, seq)})
+			case computeHash(config.HMACKey, prevHash, content) != entryHash:
+				report.Issues = append(report.Issues, ChainIssue{File: file, Line: lineNo, Seq: seq, Kind: ChainIssueModified,
+					Message: "entry doesn't match its hash"})
+			}
+
+			prevSeq = seq
+			prevHash = entryHash
+		})
+		if err != nil {
+			return report, fmt.Errorf("failed to read the audit log file %s: %w", file, err)
+		}
+	}
+	report.LastSeq = prevSeq
+
+	return report, nil
+}
+
+// chainWriter adds a sequence number and a hash chaining to the previous entry to each audit log entry written to it
+type chainWriter struct {
+	writer   io.Writer
+	hmacKey  []byte
+	seq      uint64
+	prevHash string
+	mu       sync.Mutex
+}
+
+func newChainWriter(writer io.Writer, hmacKey []byte) *chainWriter {
+	return &chainWriter{writer: writer, hmacKey: hmacKey}
+}
+
+// resume continues the hash chain from the last entry of the given audit log files
+func (c *chainWriter) resume(files []string) error {
+	for i := len(files) - 1; i >= 0; i-- {
+		found := false
+		err := readLogFile(files[i], func(_ int, line []byte) {
+			if _, seq, entryHash, ok := parseChainEntry(line); ok {
+				c.seq = seq
+				c.prevHash = entryHash
+				found = true
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Write adds the sequence number and the hash to the entry in either text or JSON format, and writes it to the
+// underlying writer. The chain isn't advanced if the entry fails to be written.
+func (c *chainWriter) Write(p []byte) (int, error) {
+	return c.write(p, c.writer.Write)
+}
+
+// WriteSeverity is the same as Write, and passes the severity of the entry to the underlying writer if it is a
+// SeverityWriter.
+func (c *chainWriter) WriteSeverity(severity Severity, p []byte) (int, error) {
+	return c.write(p, func(entry []byte) (int, error) {
+		return writeSeverity(c.writer, severity, entry)
+	})
+}
+
+func (c *chainWriter) write(p []byte, writeEntry func([]byte) (int, error)) (int, error) {
+	line := bytes.TrimRight(p, "\r\n")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seq := c.seq + 1
+	isJSON := isJSONEntry(line)
+	content := make([]byte, 0, len(line)+128)
+	if isJSON {
+		content = append(content, line[:len(line)-1]...)
+		content = append(content, jsonSequenceSep...)
+	} else {
+		content = append(content, line...)
+		content = append(content, textSequenceSep...)
+	}
+	content = strconv.AppendUint(content, seq, 10)
+
+	entryHash := computeHash(c.hmacKey, c.prevHash, content)
+	entry := content
+	if isJSON {
+		entry = append(append(append(entry, jsonHashSep...), entryHash...), '"', '}')
+	} else {
+		entry = append(append(entry, textHashSep...), entryHash...)
+	}
+	entry = append(entry, '\n')
+
+	if _, err := writeEntry(entry); err != nil {
+		return 0, err
+	}
+	c.seq = seq
+	c.prevHash = entryHash
+
+	return len(p), nil
+}
+
+// computeHash returns the hex encoded SHA-256 hash, or HMAC-SHA256 if the key is given, of the previous hash and the
+// entry content
+func computeHash(hmacKey []byte, prevHash string, content []byte) string {
+	var h hash.Hash
+	if len(hmacKey) > 0 {
+		h = hmac.New(sha256.New, hmacKey)
+	} else {
+		h = sha256.New()
+	}
+	h.Write([]byte(prevHash))
+	h.Write([]byte{'\n'})
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// parseChainEntry splits an audit log entry into the hashed content, the sequence number and the hash
+func parseChainEntry(line []byte) (content []byte, seq uint64, entryHash string, ok bool) {
+	seqSep, hashSep := textSequenceSep, textHashSep
+	if isJSONEntry(line) {
+		seqSep, hashSep = jsonSequenceSep, jsonHashSep
+		line = bytes.TrimSuffix(line[:len(line)-1], []byte{'"'})
+	}
+
+	i := bytes.LastIndex(line, hashSep)
+	if i < 0 {
+		return nil, 0, "", false
+	}
+	content, entryHash = line[:i], string(line[i+len(hashSep):])
+	if len(entryHash) != sha256.Size*2 {
+		return nil, 0, "", false
+	}
+
+	j := bytes.LastIndex(content, seqSep)
+	if j < 0 {
+		return nil, 0, "", false
+	}
+	seq, err := strconv.ParseUint(string(content[j+len(seqSep):]), 10, 64)
+	if err != nil {
+		return nil, 0, "", false
+	}
+
+	return content, seq, entryHash, true
+}
+
+// readLogFile calls fn with each non-empty line of an audit log file
+func readLogFile(path string, fn func(lineNo int, line []byte)) error {
+	file, err := openLogFile(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimRight(scanner.Bytes(), "\r")
+		if len(line) == 0 {
+			continue
+		}
+		fn(lineNo, line)
+	}
+	return scanner.Err()
+}
+
+// isJSONEntry returns whether the audit log entry is in JSON format
+func isJSONEntry(line []byte) bool {
+	return len(line) > 1 && line[0] == '{' && line[len(line)-1] == '}'
+}
@@ -0,0 +1,165 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package auditlog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	stdLog "log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testServiceName = "test-service"
+
+var testHMACKey = []byte("test-key")
+
+func TestHashChain(t *testing.T) {
+	config := Configuration{StorageDir: t.TempDir(), HashChain: true, HMACKey: testHMACKey}
+
+	logger := InitLogger(testServiceName, BaseCoverage, nil, config)
+	logger.SetEnabled(true)
+	logger.LogBase(SeverityNormal, "admin", ActionTypeLogin, "user logged in", nil)
+	logger.LogBase(SeverityCritical, "admin", ActionTypeDelete, "device deleted", LogDetails{"name": "device1"})
+
+	report, err := VerifyHashChain(testServiceName, config)
+	require.NoError(t, err)
+	assert.True(t, report.Valid(), report.Issues)
+	assert.Equal(t, 2, report.Entries)
+	assert.Equal(t, uint64(1), report.FirstSeq)
+	assert.Equal(t, uint64(2), report.LastSeq)
+
+	// The chain continues from the last entry in the file after restart
+	logger = InitLogger(testServiceName, BaseCoverage, nil, config)
+	logger.SetEnabled(true)
+	logger.LogBase(SeverityNormal, "admin", ActionTypeLogout, "user logged out", nil)
+
+	report, err = VerifyHashChain(testServiceName, config)
+	require.NoError(t, err)
+	assert.True(t, report.Valid(), report.Issues)
+	assert.Equal(t, uint64(3), report.LastSeq)
+
+	// The entries can't be verified without the HMAC key
+	report, err = VerifyHashChain(testServiceName, Configuration{StorageDir: config.StorageDir})
+	require.NoError(t, err)
+	assert.False(t, report.Valid())
+}
+
+func TestVerifyHashChainRotatedFiles(t *testing.T) {
+	config := Configuration{StorageDir: t.TempDir(), HMACKey: testHMACKey}
+	config.setDefault()
+	currentPath := logFilePath(testServiceName, config)
+	backupPath := filepath.Join(config.StorageDir, testServiceName+"-audit-2026-01-01T00-00-00.000.log.gz")
+
+	backup := &bytes.Buffer{}
+	current := &bytes.Buffer{}
+	chain := newChainWriter(backup, testHMACKey)
+	_, err := chain.Write([]byte(`level=BASE app=test severity=NORMAL actor=admin action=LOGIN desc="user logged in"` + "\n"))
+	require.NoError(t, err)
+	_, err = chain.Write([]byte(`{"level":"BASE","app":"test","severity":"NORMAL","actor":"admin","action":"READ","desc":"device read"}` + "\n"))
+	require.NoError(t, err)
+	chain.writer = current
+	for _, desc := range []string{"device created", "device updated", "device deleted"} {
+		_, err = chain.Write([]byte(`level=BASE app=test severity=CRITICAL actor=admin action=UPDATE desc="` + desc + `"` + "\n"))
+		require.NoError(t, err)
+	}
+
+	compressed := &bytes.Buffer{}
+	gz := gzip.NewWriter(compressed)
+	_, err = gz.Write(backup.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(backupPath, compressed.Bytes(), 0644))
+	require.NoError(t, os.WriteFile(currentPath, current.Bytes(), 0644))
+
+	report, err := VerifyHashChain(testServiceName, config)
+	require.NoError(t, err)
+	assert.True(t, report.Valid(), report.Issues)
+	assert.Equal(t, []string{backupPath, currentPath}, report.Files)
+	assert.Equal(t, 5, report.Entries)
+	assert.Equal(t, uint64(5), report.LastSeq)
+
+	tests := []struct {
+		name         string
+		tamper       func(lines []string) []string
+		expectedKind string
+		expectedSeq  uint64
+	}{
+		{"modified", func(lines []string) []string {
+			lines[1] = strings.Replace(lines[1], "device updated", "nothing happened", 1)
+			return lines
+		}, ChainIssueModified, 4},
+		{"deleted", func(lines []string) []string {
+			return append(lines[:1], lines[2:]...)
+		}, ChainIssueGap, 5},
+		{"malformed", func(lines []string) []string {
+			return append(lines, `level=BASE app=test severity=CRITICAL actor=admin action=UPDATE desc="forged"`)
+		}, ChainIssueMalformed, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(strings.TrimSpace(current.String()), "\n")
+			require.NoError(t, os.WriteFile(currentPath, []byte(strings.Join(tt.tamper(lines), "\n")+"\n"), 0644))
+
+			report, err := VerifyHashChain(testServiceName, config)
+			require.NoError(t, err)
+			require.Len(t, report.Issues, 1)
+			assert.Equal(t, tt.expectedKind, report.Issues[0].Kind)
+			assert.Equal(t, tt.expectedSeq, report.Issues[0].Seq)
+			assert.Equal(t, currentPath, report.Issues[0].File)
+		})
+	}
+}
+
+func TestHashChainResumeFailure(t *testing.T) {
+	config := Configuration{StorageDir: t.TempDir(), HashChain: true}
+	backupPath := filepath.Join(config.StorageDir, testServiceName+"-audit-2026-01-01T00-00-00.000.log.gz")
+	require.NoError(t, os.WriteFile(backupPath, []byte("not gzip"), 0644))
+
+	output := &bytes.Buffer{}
+	stdLog.SetOutput(output)
+	defer stdLog.SetOutput(os.Stderr)
+
+	logger := InitLogger(testServiceName, BaseCoverage, nil, config)
+	assert.Contains(t, output.String(), "failed to resume the audit log hash chain")
+
+	// The chain starts over
+	logger.SetEnabled(true)
+	logger.LogBase(SeverityNormal, "admin", ActionTypeLogin, "user logged in", nil)
+	config.setDefault()
+	content, err := os.ReadFile(logFilePath(testServiceName, config))
+	require.NoError(t, err)
+	entry, err := ParseEntry(content)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), entry.Seq)
+}
+
+type mockSecretProvider map[string]string
+
+func (m mockSecretProvider) GetSecret(secretName string, keys ...string) (map[string]string, error) {
+	if secretName != "audit" {
+		return nil, errors.New("secret not found")
+	}
+	return m, nil
+}
+
+func TestHMACKeyFromSecretProvider(t *testing.T) {
+	provider := mockSecretProvider{"key": "secret-value"}
+
+	key, err := HMACKeyFromSecretProvider(provider, "audit", "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret-value"), key)
+
+	_, err = HMACKeyFromSecretProvider(provider, "audit", "missing")
+	assert.Error(t, err)
+	_, err = HMACKeyFromSecretProvider(provider, "unknown", "key")
+	assert.Error(t, err)
+}
@@ -1,5 +1,11 @@
 package auditlog
 
+// Constants of the audit log formats
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
 type Configuration struct {
 	// StorageDir is the directory to write logs to.
 	StorageDir string
@@ -23,6 +29,20 @@ type Configuration struct {
 	// is to retain all old log files (though MaxAge may still cause them to get
 	// deleted.)
 	MaxBackups int
+
+	// Format is the format of the audit log entries, which is either text or json. It defaults to text.
+	Format string
+
+	// HashChain indicates whether each audit log entry carries a sequence number and a hash chaining it to the
+	// previous entry, so that the modified or deleted entries can be detected by VerifyHashChain. The chain is resumed
+	// from the audit log files when the service restarts, except with a custom log writer, whose chain starts over at
+	// sequence 1 on every start. Removing the oldest or the newest entries of the log isn't detected, since the chain
+	// is anchored on the first remaining entry and nothing follows the last one.
+	HashChain bool
+
+	// HMACKey is the key to sign the hash chain with HMAC-SHA256. The hash chain is computed with SHA-256 if it is
+	// empty. HMACKeyFromSecretProvider can be used to retrieve the key from the secret store.
+	HMACKey []byte
 }
 
 var defaultConfig = Configuration{
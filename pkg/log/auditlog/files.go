@@ -0,0 +1,109 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package auditlog
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// backupTimeFormat is the timestamp format which lumberjack encodes in the name of the rotated files
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+)
+
+// logFilePath returns the path of the current audit log file of the service
+func logFilePath(owningServiceName string, config Configuration) string {
+	// Add the service name to the log file name as a prefix
+	return filepath.Join(config.StorageDir, owningServiceName+"-"+config.FileName)
+}
+
+// logFiles returns the rotated audit log files followed by the current one in chronological order. Files that don't
+// exist are not included.
+func logFiles(filePath string) ([]string, error) {
+	dir := filepath.Dir(filePath)
+	name := filepath.Base(filePath)
+	ext := filepath.Ext(name)
+	prefix := strings.TrimSuffix(name, ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	type backup struct {
+		path      string
+		timestamp time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		ts := strings.TrimPrefix(entry.Name(), prefix)
+		ts = strings.TrimSuffix(ts, compressSuffix)
+		if !strings.HasSuffix(ts, ext) {
+			continue
+		}
+		t, err := time.Parse(backupTimeFormat, strings.TrimSuffix(ts, ext))
+		if err != nil {
+			// not a backup file created by lumberjack
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, entry.Name()), timestamp: t})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.Before(backups[j].timestamp)
+	})
+
+	files := make([]string, 0, len(backups)+1)
+	for _, b := range backups {
+		files = append(files, b.path)
+	}
+	if _, err := os.Stat(filePath); err == nil {
+		files = append(files, filePath)
+	}
+	return files, nil
+}
+
+// openLogFile opens an audit log file for reading, which is decompressed if it is a compressed rotated file
+func openLogFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, compressSuffix) {
+		return file, nil
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &gzipFile{Reader: gz, file: file}, nil
+}
+
+// gzipFile closes both the gzip reader and the underlying file
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	err := g.Reader.Close()
+	if fileErr := g.file.Close(); err == nil {
+		err = fileErr
+	}
+	return err
+}
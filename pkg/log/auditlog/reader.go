@@ -0,0 +1,211 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Entry is an audit log entry read from the audit log files
+type Entry struct {
+	Timestamp     time.Time  `json:"ts"`
+	App           string     `json:"app"`
+	CoverageLevel string     `json:"level"`
+	Severity      Severity   `json:"severity"`
+	Actor         string     `json:"actor"`
+	Action        ActionType `json:"action"`
+	Description   string     `json:"desc"`
+	// Details is the details object of the entries in JSON format, or the details string of the entries in text format
+	Details any    `json:"details,omitempty"`
+	Seq     uint64 `json:"seq,omitempty"`
+}
+
+// Filter defines the conditions of the audit log entries to query. The empty fields match any entry.
+type Filter struct {
+	Actor    string
+	Action   ActionType
+	Severity Severity
+	Start    time.Time
+	End      time.Time
+}
+
+// match returns whether the entry satisfies the filter
+func (f Filter) match(e Entry) bool {
+	if f.Actor != "" && f.Actor != e.Actor {
+		return false
+	}
+	if f.Action != "" && f.Action != e.Action {
+		return false
+	}
+	if f.Severity != "" && f.Severity != e.Severity {
+		return false
+	}
+	if !f.Start.IsZero() && e.Timestamp.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && e.Timestamp.After(f.End) {
+		return false
+	}
+	return true
+}
+
+// Reader reads the audit log entries from the current and rotated audit log files written by the Logger
+type Reader struct {
+	filePath string
+}
+
+// NewReader creates a Reader of the audit log files of the service. The config should be the same as the one passed
+// to InitLogger.
+func NewReader(owningServiceName string, config Configuration) *Reader {
+	config.setDefault()
+	return &Reader{filePath: logFilePath(owningServiceName, config)}
+}
+
+// Query returns the audit log entries matching the filter from the newest to the oldest, starting at offset and
+// containing at most limit entries, and the total count of the matching entries. All the matching entries after
+// offset are returned if limit is negative. The lines which can't be parsed are skipped. An error is returned if offset
+// is negative.
+func (r *Reader) Query(filter Filter, offset, limit int) ([]Entry, int64, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("invalid offset %d, the offset must be greater than or equal to 0", offset)
+	}
+
+	files, err := logFiles(r.filePath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list the audit log files: %w", err)
+	}
+
+	// The files are streamed from the oldest to the newest, so only the newest offset+limit matching entries are kept
+	// in a ring buffer while all of them are counted
+	keep := -1
+	if limit >= 0 {
+		keep = offset + limit
+	}
+	var kept []Entry
+	next := 0
+	var totalCount int64
+	for _, file := range files {
+		err := readLogFile(file, func(_ int, line []byte) {
+			entry, err := ParseEntry(line)
+			if err != nil || !filter.match(entry) {
+				return
+			}
+			totalCount++
+			switch {
+			case keep < 0 || len(kept) < keep:
+				kept = append(kept, entry)
+			case keep > 0:
+				kept[next] = entry
+				next = (next + 1) % keep
+			}
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read the audit log file %s: %w", file, err)
+		}
+	}
+
+	// The oldest kept entry is at next once the ring buffer has wrapped around, so the page is picked in reverse order
+	// starting from the entry before it
+	if offset >= len(kept) {
+		return []Entry{}, totalCount, nil
+	}
+	entries := make([]Entry, 0, len(kept)-offset)
+	for i := offset; i < len(kept); i++ {
+		entries = append(entries, kept[(next-1-i+2*len(kept))%len(kept)])
+	}
+	return entries, totalCount, nil
+}
+
+// ParseEntry parses an audit log entry written by the Logger in either text or JSON format, e.g. to read the fields of
+// the entries delivered to a custom log writer
+func ParseEntry(line []byte) (Entry, error) {
+	line = bytes.TrimRight(line, "\r\n")
+	var entry Entry
+	if isJSONEntry(line) {
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return entry, err
+		}
+		return entry, nil
+	}
+
+	fields, err := parseTextFields(line)
+	if err != nil {
+		return entry, err
+	}
+	entry.Timestamp, err = time.Parse(time.RFC3339Nano, fields[TimestampKey])
+	if err != nil {
+		return entry, err
+	}
+	entry.App = fields[appKey]
+	entry.CoverageLevel = fields["level"]
+	entry.Severity = Severity(fields[SeverityKey])
+	entry.Actor = fields[ActorKey]
+	entry.Action = ActionType(fields[ActionKey])
+	entry.Description = fields[DescriptionKey]
+	if details, ok := fields[DetailsKey]; ok {
+		entry.Details = details
+	}
+	if seq, ok := fields[SequenceKey]; ok {
+		entry.Seq, _ = strconv.ParseUint(seq, 10, 64)
+	}
+	return entry, nil
+}
+
+// parseTextFields parses the key=value pairs of an audit log entry in text format, where the values containing
+// spaces or special characters are quoted by the slog text handler
+func parseTextFields(line []byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	for len(line) > 0 {
+		line = bytes.TrimLeft(line, " ")
+		if len(line) == 0 {
+			break
+		}
+		i := bytes.IndexByte(line, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid audit log entry")
+		}
+		key := string(line[:i])
+		line = line[i+1:]
+
+		if len(line) > 0 && line[0] == '"' {
+			end := closingQuote(line)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted value of %s", key)
+			}
+			value, err := strconv.Unquote(string(line[:end+1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted value of %s: %w", key, err)
+			}
+			fields[key] = value
+			line = line[end+1:]
+			continue
+		}
+
+		end := bytes.IndexByte(line, ' ')
+		if end < 0 {
+			end = len(line)
+		}
+		fields[key] = string(line[:end])
+		line = line[end:]
+	}
+	return fields, nil
+}
+
+// closingQuote returns the index of the quote closing the quoted value at the beginning of s
+func closingQuote(s []byte) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
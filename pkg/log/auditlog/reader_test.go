@@ -0,0 +1,120 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package auditlog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderQuery(t *testing.T) {
+	for _, format := range []string{TextFormat, JSONFormat} {
+		t.Run(format, func(t *testing.T) {
+			config := Configuration{StorageDir: t.TempDir(), Format: format, HashChain: true}
+
+			// Write the older entries to a compressed rotated file, which is recent enough not to be removed by the log
+			// rotation
+			backup := &bytes.Buffer{}
+			logger := InitLogger(testServiceName, FullCoverage, backup, config)
+			logger.SetEnabled(true)
+			logger.LogBase(SeverityNormal, "admin", ActionTypeLogin, "admin logged in", nil)
+			logger.LogFull(SeverityMinor, "operator", ActionTypeRead, "device read", nil)
+			writeCompressedFile(t, filepath.Join(config.StorageDir, testServiceName+"-audit-"+time.Now().Add(-time.Hour).Format(backupTimeFormat)+".log.gz"), backup.Bytes())
+
+			// The timestamps of the text format are in milliseconds, so make sure the entries are logged in different
+			// milliseconds
+			time.Sleep(10 * time.Millisecond)
+			before := time.Now().Truncate(time.Millisecond)
+			logger = InitLogger(testServiceName, FullCoverage, nil, config)
+			logger.SetEnabled(true)
+			logger.LogBase(SeverityCritical, "admin", ActionTypeDelete, "device deleted", LogDetails{"name": "device 1"})
+			logger.LogAdvanced(SeverityNormal, "operator", ActionTypeUpdate, `device "2" updated`, nil)
+
+			reader := NewReader(testServiceName, config)
+
+			entries, totalCount, err := reader.Query(Filter{}, 0, -1)
+			require.NoError(t, err)
+			assert.Equal(t, int64(4), totalCount)
+			require.Len(t, entries, 4)
+			assert.Equal(t, `device "2" updated`, entries[0].Description)
+			assert.Equal(t, AdvancedCoverage, entries[0].CoverageLevel)
+			assert.Equal(t, "admin logged in", entries[3].Description)
+			assert.Equal(t, testServiceName, entries[3].App)
+			assert.Equal(t, uint64(1), entries[3].Seq)
+			assert.NotNil(t, entries[1].Details)
+
+			tests := []struct {
+				name                 string
+				filter               Filter
+				offset               int
+				limit                int
+				expectedTotalCount   int64
+				expectedDescriptions []string
+			}{
+				{"actor", Filter{Actor: "operator"}, 0, -1, 2, []string{`device "2" updated`, "device read"}},
+				{"action", Filter{Action: ActionTypeDelete}, 0, -1, 1, []string{"device deleted"}},
+				{"severity", Filter{Severity: SeverityNormal}, 0, -1, 2, []string{`device "2" updated`, "admin logged in"}},
+				{"time range", Filter{Start: before}, 0, -1, 2, []string{`device "2" updated`, "device deleted"}},
+				{"first page", Filter{}, 0, 1, 4, []string{`device "2" updated`}},
+				{"paging", Filter{}, 1, 2, 4, []string{"device deleted", "device read"}},
+				{"last page", Filter{}, 3, 2, 4, []string{"admin logged in"}},
+				{"zero limit", Filter{}, 0, 0, 4, []string{}},
+				{"offset out of range", Filter{}, 10, 2, 4, []string{}},
+				{"no match", Filter{Actor: "unknown"}, 0, 20, 0, []string{}},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					entries, totalCount, err := reader.Query(tt.filter, tt.offset, tt.limit)
+					require.NoError(t, err)
+					assert.Equal(t, tt.expectedTotalCount, totalCount)
+					descriptions := []string{}
+					for _, entry := range entries {
+						descriptions = append(descriptions, entry.Description)
+					}
+					assert.Equal(t, tt.expectedDescriptions, descriptions)
+				})
+			}
+
+			// a negative offset is rejected whether there are matching entries or not
+			_, _, err = reader.Query(Filter{}, -1, 2)
+			assert.Error(t, err)
+			_, _, err = reader.Query(Filter{Actor: "unknown"}, -1, 2)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseTextFields(t *testing.T) {
+	fields, err := parseTextFields([]byte(`level=BASE app=test desc="a \"quoted\" value" details="map[name:device 1]" seq=1`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"level":   "BASE",
+		"app":     "test",
+		"desc":    `a "quoted" value`,
+		"details": "map[name:device 1]",
+		"seq":     "1",
+	}, fields)
+
+	_, err = parseTextFields([]byte(`level=BASE desc="unterminated`))
+	assert.Error(t, err)
+	_, err = parseTextFields([]byte(`not an entry`))
+	assert.Error(t, err)
+}
+
+func writeCompressedFile(t *testing.T, path string, data []byte) {
+	compressed := &bytes.Buffer{}
+	gz := gzip.NewWriter(compressed)
+	_, err := gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(path, compressed.Bytes(), 0644))
+}
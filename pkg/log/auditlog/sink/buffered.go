@@ -0,0 +1,94 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/re_exec"
+)
+
+// BufferConfiguration defines the persistent buffer of the undeliverable audit log entries of a sink
+type BufferConfiguration struct {
+	// StorageDir is the directory of the buffer files, which are named after the sinks. It defaults to
+	// /tmp/log/audit/buffer.
+	StorageDir string
+
+	// QueueLimit is the maximum number of the buffered entries. It defaults to re_exec.DefaultMaxQueueLimit.
+	QueueLimit int
+
+	// RetryInterval is the interval of replaying the buffered entries. It defaults to re_exec.DefaultRetryInterval.
+	RetryInterval string
+}
+
+var defaultBufferConfig = BufferConfiguration{
+	StorageDir: "/tmp/log/audit/buffer",
+}
+
+func (c *BufferConfiguration) setDefault() {
+	if c.StorageDir == "" {
+		c.StorageDir = defaultBufferConfig.StorageDir
+	}
+}
+
+type bufferedSink struct {
+	sink  Sink
+	queue common.Queue[string]
+	// failureLogger is a sampled logger for the repetitive traces of buffering the entries
+	failureLogger log.Logger
+}
+
+// NewBufferedSink wraps a remote sink with a persistent buffer. The entries failing to be delivered are buffered on
+// disk and replayed at the retry interval until the context is done, with the retry semantics of the re_exec queues.
+// The new entries are buffered behind while the buffer isn't empty, so that the entries are delivered in order.
+func NewBufferedSink(dic *di.Container, ctx context.Context, sink Sink, config BufferConfiguration) (Sink, error) {
+	config.setDefault()
+	if err := os.MkdirAll(config.StorageDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the audit log buffer directory %s: %w", config.StorageDir, err)
+	}
+
+	replay := func(_ context.Context, _ *di.Container, entry string) bool {
+		return sink.Write([]byte(entry)) == nil
+	}
+	filePath := filepath.Join(config.StorageDir, sink.Name()+".buffer")
+	queue, err := re_exec.NewFileQueue[string](dic, ctx, filePath, config.QueueLimit, config.RetryInterval, replay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bufferedSink{
+		sink:          sink,
+		queue:         queue,
+		failureLogger: log.Sampled(container.LoggerFrom(dic.Get), failureLogSampling),
+	}, nil
+}
+
+func (s *bufferedSink) Name() string {
+	return s.sink.Name()
+}
+
+// Write delivers the entry to the wrapped sink, or buffers it if the delivery fails or there are buffered entries.
+// An error is only returned if the entry can't be buffered.
+func (s *bufferedSink) Write(entry []byte) error {
+	if s.queue.Size() == 0 {
+		err := s.sink.Write(entry)
+		if err == nil {
+			return nil
+		}
+		s.failureLogger.Warnf("Failed to write the audit log entry to sink '%s', buffer it for retry: %v", s.sink.Name(), err)
+	}
+
+	if err := s.queue.Enqueue(string(entry)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sink
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/syslog"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+// Names of the built-in sinks
+const (
+	FileSinkName   = "file"
+	SyslogSinkName = "syslog"
+)
+
+// failureLogSampling limits the traces of the repetitive failures of a sink
+var failureLogSampling = log.SamplingConfig{Interval: 10 * time.Second, First: 1}
+
+// Sink is a destination of the audit log entries
+type Sink interface {
+	// Name returns the name of the sink, which identifies the sink in the traces and the buffer file
+	Name() string
+	// Write delivers an audit log entry to the sink
+	Write(entry []byte) error
+}
+
+type writerSink struct {
+	name   string
+	writer io.Writer
+}
+
+// NewWriterSink creates a Sink writing the audit log entries to the given io.Writer
+func NewWriterSink(name string, writer io.Writer) Sink {
+	return &writerSink{name: name, writer: writer}
+}
+
+// NewFileSink creates a Sink writing the audit log entries to the rotated audit log file of the service
+func NewFileSink(owningServiceName string, config auditlog.Configuration) (Sink, error) {
+	writer, err := auditlog.NewFileWriter(owningServiceName, config)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterSink(FileSinkName, writer), nil
+}
+
+func (s *writerSink) Name() string {
+	return s.name
+}
+
+func (s *writerSink) Write(entry []byte) error {
+	_, err := s.writer.Write(entry)
+	return err
+}
+
+type syslogSink struct {
+	sender *syslog.Sender
+}
+
+// NewSyslogSink creates a Sink sending the audit log entries to a remote syslog collector synchronously, so that the
+// failure of the delivery is returned. The sink is typically wrapped by NewBufferedSink, so that the entries are
+// retried while the collector is unavailable. The syslog severity is mapped from the severity of each entry.
+func NewSyslogSink(config syslog.Configuration) (Sink, error) {
+	sender, err := syslog.NewSender(config)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{sender: sender}, nil
+}
+
+func (s *syslogSink) Name() string {
+	return SyslogSinkName
+}
+
+func (s *syslogSink) Write(entry []byte) error {
+	// The entries which can't be parsed are sent with the default severity of the audit log
+	severity := auditlog.SeverityNormal
+	if e, err := auditlog.ParseEntry(entry); err == nil && e.Severity != "" {
+		severity = e.Severity
+	}
+	return s.sender.Send(syslog.SeverityFromAuditSeverity(severity), entry)
+}
+
+type httpSink struct {
+	name   string
+	dic    *di.Container
+	sender rest.HTTPSender
+}
+
+// NewHTTPSink creates a Sink posting each audit log entry to an HTTP collector with the given HTTPSender. The sink
+// is typically wrapped by NewBufferedSink, so that the entries are retried while the collector is unavailable.
+func NewHTTPSink(name string, dic *di.Container, sender rest.HTTPSender) Sink {
+	return &httpSink{name: name, dic: dic, sender: sender}
+}
+
+func (s *httpSink) Name() string {
+	return s.name
+}
+
+func (s *httpSink) Write(entry []byte) error {
+	if err := s.sender.HTTPPost(s.dic, bytes.TrimRight(entry, "\n")); err != nil {
+		return err
+	}
+	return nil
+}
+
+type fanOutWriter struct {
+	sinks []Sink
+	// failureLoggers are the sampled loggers of each sink for the repetitive traces of the failures
+	failureLoggers []log.Logger
+}
+
+// NewFanOutWriter creates an io.Writer delivering each audit log entry to all the sinks in order, which can be used as
+// the logWriter of auditlog.InitLogger. The failure of a sink is logged and doesn't affect the other sinks, and an
+// error is only returned if all the sinks fail.
+func NewFanOutWriter(dic *di.Container, sinks ...Sink) io.Writer {
+	logger := container.LoggerFrom(dic.Get)
+	w := &fanOutWriter{sinks: sinks}
+	for range sinks {
+		w.failureLoggers = append(w.failureLoggers, log.Sampled(logger, failureLogSampling))
+	}
+	return w
+}
+
+func (w *fanOutWriter) Write(p []byte) (int, error) {
+	// The slog handler reuses the buffer, so the entry is copied for the sinks which keep it
+	entry := bytes.Clone(p)
+
+	var errs []error
+	for i, s := range w.sinks {
+		if err := s.Write(entry); err != nil {
+			w.failureLoggers[i].Errorf("Failed to write the audit log entry to sink '%s': %v", s.Name(), err)
+			errs = append(errs, fmt.Errorf("sink %s: %w", s.Name(), err))
+		}
+	}
+	if len(errs) > 0 && len(errs) == len(w.sinks) {
+		return 0, errors.Join(errs...)
+	}
+
+	return len(p), nil
+}
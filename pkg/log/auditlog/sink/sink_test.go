@@ -0,0 +1,215 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/syslog"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+const testServiceName = "test-service"
+
+type failingSink struct{}
+
+func (failingSink) Name() string {
+	return "failing"
+}
+
+func (failingSink) Write([]byte) error {
+	return errors.New("sink is unavailable")
+}
+
+// collector is an HTTP collector which rejects the entries until it is available
+type collector struct {
+	available atomic.Bool
+	entries   []map[string]any
+	mu        sync.Mutex
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !c.available.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	var entry map[string]any
+	if err := json.Unmarshal(body, &entry); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	c.entries = append(c.entries, entry)
+	c.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *collector) descriptions() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var descriptions []string
+	for _, entry := range c.entries {
+		descriptions = append(descriptions, entry[auditlog.DescriptionKey].(string))
+	}
+	return descriptions
+}
+
+func newTestDic() *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		container.LoggerInterfaceName: func(get di.Get) any {
+			return log.NewNopeLogger()
+		},
+	})
+}
+
+func TestFanOutWithBufferedHTTPSink(t *testing.T) {
+	dic := newTestDic()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	config := auditlog.Configuration{StorageDir: t.TempDir(), Format: auditlog.JSONFormat}
+	fileSink, err := NewFileSink(testServiceName, config)
+	require.NoError(t, err)
+	httpSink, err := NewBufferedSink(dic, ctx, NewHTTPSink("collector", dic, rest.NewHTTPSender(server.URL, common.ContentTypeJSON)),
+		BufferConfiguration{StorageDir: t.TempDir(), RetryInterval: "10ms"})
+	require.NoError(t, err)
+
+	logger := auditlog.InitLogger(testServiceName, auditlog.BaseCoverage, NewFanOutWriter(dic, fileSink, httpSink, failingSink{}), config)
+	logger.SetEnabled(true)
+	logger.LogBase(auditlog.SeverityNormal, "admin", auditlog.ActionTypeLogin, "user logged in", nil)
+	logger.LogBase(auditlog.SeverityCritical, "admin", auditlog.ActionTypeDelete, "device deleted", nil)
+
+	// The entries are written to the file regardless of the failures of the other sinks
+	entries, totalCount, err := auditlog.NewReader(testServiceName, config).Query(auditlog.Filter{}, 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), totalCount)
+	assert.Equal(t, "device deleted", entries[0].Description)
+	assert.Empty(t, c.descriptions())
+
+	// The buffered entries are replayed in order once the collector is available
+	c.available.Store(true)
+	require.Eventually(t, func() bool {
+		return len(c.descriptions()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	logger.LogBase(auditlog.SeverityNormal, "admin", auditlog.ActionTypeLogout, "user logged out", nil)
+	require.Eventually(t, func() bool {
+		return len(c.descriptions()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"user logged in", "device deleted", "user logged out"}, c.descriptions())
+}
+
+func TestFanOutAllSinksFail(t *testing.T) {
+	w := NewFanOutWriter(newTestDic(), failingSink{}, failingSink{})
+	_, err := w.Write([]byte("entry\n"))
+	assert.Error(t, err)
+}
+
+func TestBufferedSinkPersistence(t *testing.T) {
+	dic := newTestDic()
+	bufferConfig := BufferConfiguration{StorageDir: t.TempDir(), RetryInterval: "1h"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	buffered, err := NewBufferedSink(dic, ctx, failingSink{}, bufferConfig)
+	require.NoError(t, err)
+	require.NoError(t, buffered.Write([]byte("entry 1\n")))
+	require.NoError(t, buffered.Write([]byte("entry 2\n")))
+	cancel()
+
+	// The buffered entries are replayed after restart
+	received := make(chan string, 2)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	bufferConfig.RetryInterval = "10ms"
+	_, err = NewBufferedSink(dic, ctx, NewWriterSink(failingSink{}.Name(), writerFunc(func(p []byte) (int, error) {
+		received <- string(p)
+		return len(p), nil
+	})), bufferConfig)
+	require.NoError(t, err)
+
+	for _, expected := range []string{"entry 1\n", "entry 2\n"} {
+		select {
+		case entry := <-received:
+			assert.Equal(t, expected, entry)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the buffered entries are not replayed")
+		}
+	}
+}
+
+func TestBufferedSyslogSink(t *testing.T) {
+	dic := newTestDic()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Reserve a free port and release it, so that the collector is down when the entries are written
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	syslogSink, err := NewSyslogSink(syslog.Configuration{Network: syslog.NetworkTCP, Address: addr, Facility: syslog.FacilityAuthPriv, WriteTimeout: time.Second})
+	require.NoError(t, err)
+	assert.Error(t, syslogSink.Write([]byte("entry\n")))
+	buffered, err := NewBufferedSink(dic, ctx, syslogSink, BufferConfiguration{StorageDir: t.TempDir(), RetryInterval: "10ms"})
+	require.NoError(t, err)
+
+	logger := auditlog.InitLogger(testServiceName, auditlog.BaseCoverage, NewFanOutWriter(dic, buffered), auditlog.Configuration{})
+	logger.SetEnabled(true)
+	logger.LogBase(auditlog.SeverityCritical, "admin", auditlog.ActionTypeDelete, "device deleted", nil)
+	assert.Equal(t, 1, buffered.(*bufferedSink).queue.Size())
+
+	// The buffered entry is replayed once the collector is up
+	l, err = net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer l.Close()
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	reader := bufio.NewReader(conn)
+	length, err := reader.ReadString(' ')
+	require.NoError(t, err)
+	n, err := strconv.Atoi(strings.TrimSpace(length))
+	require.NoError(t, err)
+	buf := make([]byte, n)
+	_, err = io.ReadFull(reader, buf)
+	require.NoError(t, err)
+	frame := string(buf)
+	// authpriv (10) * 8 + critical (2)
+	assert.True(t, strings.HasPrefix(frame, "<82>1 "), frame)
+	assert.Contains(t, frame, `desc="device deleted"`)
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
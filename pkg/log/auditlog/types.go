@@ -4,7 +4,10 @@
 
 package auditlog
 
-import "log/slog"
+import (
+	"io"
+	"log/slog"
+)
 
 // Constants of coverage level which can be used to label and group audit log by their coverage level.
 const (
@@ -36,6 +39,18 @@ func slogLevelFromString(coverageLevel string) slog.Level {
 	}
 }
 
+// coverageLevelFromSlogLevel returns the coverage level for the given slog level.
+func coverageLevelFromSlogLevel(level slog.Level) string {
+	switch level {
+	case FullCoverageLevel:
+		return FullCoverage
+	case AdvancedCoverageLevel:
+		return AdvancedCoverage
+	default:
+		return BaseCoverage
+	}
+}
+
 // ActionType is a categorical identifier used to give high-level insight as to the action type.
 type ActionType string
 
@@ -86,6 +101,14 @@ const (
 	SeverityMinor    Severity = "MINOR"
 )
 
+// SeverityWriter is an optional interface of the log writer, which receives the severity of each audit log entry along
+// with the formatted entry, so that the writer doesn't have to parse the severity out of the entry, e.g. to map it to
+// the syslog severity.
+type SeverityWriter interface {
+	io.Writer
+	WriteSeverity(severity Severity, p []byte) (int, error)
+}
+
 // LogDetails is a detailed mapping to set extra information with the audit log
 type LogDetails map[string]any
 
@@ -103,3 +126,12 @@ type Logger interface {
 	// LogFull adds an audit log entry to the log writer with full coverage level
 	LogFull(severity Severity, actor string, action ActionType, description string, details LogDetails)
 }
+
+// SettingsReporter is an optional interface of Logger reporting its current settings, which is implemented by the
+// Logger created by InitLogger. The callers should check for it with a type assertion.
+type SettingsReporter interface {
+	// Enabled returns the enabled status of the logger
+	Enabled() bool
+	// CoverageLevel returns the current coverage level setting
+	CoverageLevel() string
+}
@@ -0,0 +1,98 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// ComponentKey is the key of the component name added to the log entries of a component Logger
+const ComponentKey = "component"
+
+// ComponentLogger defines the interface for a Logger which can derive named component loggers, e.g. "sse", "secrets"
+// or "auth", whose log levels can be set independently of the service log level.
+type ComponentLogger interface {
+	Logger
+	// Component returns a child Logger for the named component. The component Logger uses the log level set by
+	// SetComponentLogLevel, or falls back to the service log level if it is not set. Calling SetLogLevel on the
+	// component Logger only changes the log level of that component.
+	Component(name string) Logger
+	// SetComponentLogLevel sets the minimum severity log level of the named component. An empty log level removes
+	// the component log level so that the component falls back to the service log level.
+	SetComponentLogLevel(name string, logLevel string) error
+	// ComponentLogLevels returns the log levels which have been set for the components
+	ComponentLogLevels() map[string]string
+}
+
+// Component returns a child Logger for the named component if the given logger implements ComponentLogger.
+// Otherwise, the given logger is returned as is.
+func Component(logger Logger, name string) Logger {
+	if cl, ok := logger.(ComponentLogger); ok {
+		return cl.Component(name)
+	}
+	return logger
+}
+
+// componentLevels holds the log levels of the components which are shared by all the loggers derived from the same
+// root logger
+type componentLevels struct {
+	mu     sync.RWMutex
+	levels map[string]string
+}
+
+func newComponentLevels() *componentLevels {
+	return &componentLevels{levels: make(map[string]string)}
+}
+
+func (c *componentLevels) get(name string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	logLevel, ok := c.levels[name]
+	return logLevel, ok
+}
+
+func (c *componentLevels) set(name string, logLevel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if logLevel == "" {
+		delete(c.levels, name)
+		return
+	}
+	c.levels[name] = logLevel
+}
+
+func (c *componentLevels) all() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return maps.Clone(c.levels)
+}
+
+func (l logger) Component(name string) Logger {
+	if name == "" || name == l.component {
+		return l
+	}
+
+	child := l.With(ComponentKey, name).(logger)
+	child.component = name
+	return child
+}
+
+func (l logger) SetComponentLogLevel(name string, logLevel string) error {
+	if logLevel != "" && !isValidLogLevel(logLevel) {
+		return fmt.Errorf("invalid log level `%s` for component `%s`", logLevel, name)
+	}
+
+	l.componentLevels.set(name, logLevel)
+	return nil
+}
+
+func (l logger) ComponentLogLevels() map[string]string {
+	return l.componentLevels.all()
+}
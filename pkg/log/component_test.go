@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponent(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLogger("testService", InfoLog, buf)
+	sseLogger := Component(logger, "sse")
+
+	// component logger falls back to the service log level
+	assert.Equal(t, InfoLog, sseLogger.LogLevel())
+	sseLogger.Debug("test debug log")
+	assert.Empty(t, buf.String())
+
+	// component log level does not affect the service log level
+	err := logger.(ComponentLogger).SetComponentLogLevel("sse", DebugLog)
+	require.NoError(t, err)
+	assert.Equal(t, DebugLog, sseLogger.LogLevel())
+	assert.Equal(t, InfoLog, logger.LogLevel())
+
+	sseLogger.Debug("test debug log")
+	assert.Contains(t, buf.String(), ComponentKey+"=sse")
+	assert.Contains(t, buf.String(), "test debug log")
+
+	buf.Reset()
+	logger.Debug("test debug log")
+	assert.Empty(t, buf.String())
+
+	// SetLogLevel on the component logger only changes the component log level
+	err = sseLogger.SetLogLevel(TraceLog)
+	require.NoError(t, err)
+	assert.Equal(t, TraceLog, sseLogger.LogLevel())
+	assert.Equal(t, InfoLog, logger.LogLevel())
+	assert.Equal(t, map[string]string{"sse": TraceLog}, logger.(ComponentLogger).ComponentLogLevels())
+
+	// empty log level removes the component log level
+	err = logger.(ComponentLogger).SetComponentLogLevel("sse", "")
+	require.NoError(t, err)
+	assert.Equal(t, InfoLog, sseLogger.LogLevel())
+	assert.Empty(t, logger.(ComponentLogger).ComponentLogLevels())
+}
+
+func TestSetComponentLogLevelInvalid(t *testing.T) {
+	logger := InitLogger("testService", InfoLog, nil)
+	err := logger.(ComponentLogger).SetComponentLogLevel("sse", "INF")
+	assert.Error(t, err)
+}
+
+func TestComponentWithoutComponentLogger(t *testing.T) {
+	logger := NewNopeLogger()
+	assert.Equal(t, logger, Component(logger, "sse"))
+}
@@ -0,0 +1,22 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"context"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+)
+
+// FromContext returns a child Logger of the given logger which binds the correlation ID placed into the request
+// context by the handlers.ManageHeader middleware. The given logger is returned as is if the context doesn't carry
+// a correlation ID.
+func FromContext(ctx context.Context, logger Logger) Logger {
+	correlationId, ok := ctx.Value(common.CorrelationID).(string)
+	if !ok || correlationId == "" {
+		return logger
+	}
+	return logger.With(common.CorrelationID, correlationId)
+}
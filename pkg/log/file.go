@@ -0,0 +1,193 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileConfiguration defines the file output and log rotation of the service logger
+type FileConfiguration struct {
+	// Enabled indicates whether the service log is written to file instead of STDOUT.
+	Enabled bool
+
+	// StorageDir is the directory to write logs to. It defaults to /tmp/log.
+	StorageDir string
+
+	// FileName is the file to write logs to, which is prefixed with the service name. Backup log files will be
+	// retained in the same directory. It defaults to service.log.
+	FileName string
+
+	// MaxSize is the maximum size in megabytes of the log file before it gets rotated. It defaults to 100 megabytes.
+	MaxSize int
+
+	// MaxAge is the maximum number of days to retain old log files based on the timestamp encoded in their filename.
+	// It defaults to 28 days.
+	MaxAge int
+
+	// MaxBackups is the maximum number of old log files to retain. It defaults to 3.
+	MaxBackups int
+
+	// TeeToStdout indicates whether the service log is also written to STDOUT simultaneously.
+	TeeToStdout bool
+}
+
+var defaultFileConfig = FileConfiguration{
+	StorageDir: "/tmp/log",
+	FileName:   "service.log",
+	MaxSize:    100,
+	MaxAge:     28,
+	MaxBackups: 3,
+}
+
+func (c *FileConfiguration) setDefault() {
+	if c.StorageDir == "" {
+		c.StorageDir = defaultFileConfig.StorageDir
+	}
+	if c.FileName == "" {
+		c.FileName = defaultFileConfig.FileName
+	}
+	if c.MaxSize == 0 {
+		c.MaxSize = defaultFileConfig.MaxSize
+	}
+	if c.MaxAge == 0 {
+		c.MaxAge = defaultFileConfig.MaxAge
+	}
+	if c.MaxBackups == 0 {
+		c.MaxBackups = defaultFileConfig.MaxBackups
+	}
+}
+
+// NewFileWriter creates a rotated file writer according to the given configuration, which can be used as the
+// logWriter of InitLogger. The returned writer also writes to STDOUT if TeeToStdout is set. An error is returned if
+// the log file can't be written.
+func NewFileWriter(owningServiceName string, config FileConfiguration) (io.Writer, error) {
+	config.setDefault()
+
+	// Add the service name to the log file name as a prefix
+	filePath := filepath.Join(config.StorageDir, owningServiceName+"-"+config.FileName)
+	if err := checkFileWritable(filePath); err != nil {
+		return nil, fmt.Errorf("log file %s is not writable: %w", filePath, err)
+	}
+
+	file := &lumberjack.Logger{
+		Filename:   filePath,
+		MaxSize:    config.MaxSize, // megabytes
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAge, // days
+		LocalTime:  true,
+		Compress:   true,
+	}
+	logWriter := &fileWriter{Writer: file, file: file}
+	if config.TeeToStdout {
+		logWriter.Writer = io.MultiWriter(file, os.Stdout)
+	}
+
+	return logWriter, nil
+}
+
+// fileWriter is the log writer created by NewFileWriter, which closes the log file when it is closed
+type fileWriter struct {
+	io.Writer
+	file io.Closer
+}
+
+func (w *fileWriter) Close() error {
+	return w.file.Close()
+}
+
+// checkFileWritable checks if the file can be created or opened for writing
+func checkFileWritable(filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// SetLogWriter changes the writer of the given logger, e.g. to write to the file writer created by NewFileWriter
+// once the configuration is loaded. The replaced writer is closed if it was created by NewFileWriter, so that the
+// previous log file isn't left open. An error is returned if the logger doesn't support changing the writer.
+func SetLogWriter(lc Logger, logWriter io.Writer) error {
+	l, ok := lc.(logger)
+	if !ok {
+		return errors.New("the logger doesn't support changing the log writer")
+	}
+
+	// The other writers, e.g. os.Stdout, are owned by the callers and are kept open
+	replaced := l.logWriter.set(logWriter)
+	if fw, ok := replaced.(*fileWriter); ok && fw != logWriter {
+		// The log has been switched to the new writer already, so the error of closing the previous file is ignored
+		_ = fw.Close()
+	}
+	return nil
+}
+
+// LevelWriter is an optional interface of the log writer, which receives the level of each log entry along with the
+// formatted entry, so that the writer doesn't have to parse the level out of the entry, e.g. to map it to the syslog
+// severity.
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(logLevel string, p []byte) (int, error)
+}
+
+// levelEntryWriter writes a single log entry of the given level to the swapWriter
+type levelEntryWriter struct {
+	writer   *swapWriter
+	logLevel string
+}
+
+func (w levelEntryWriter) Write(p []byte) (int, error) {
+	return w.writer.writeLevel(w.logLevel, p)
+}
+
+// swapWriter is an io.Writer whose underlying writer can be changed at runtime
+type swapWriter struct {
+	mu sync.RWMutex
+	w  io.Writer
+}
+
+func newSwapWriter(w io.Writer) *swapWriter {
+	return &swapWriter{w: w}
+}
+
+func (s *swapWriter) Write(p []byte) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.w.Write(p)
+}
+
+// writeLevel passes the log level along with the log entry if the underlying writer is a LevelWriter
+func (s *swapWriter) writeLevel(logLevel string, p []byte) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if lw, ok := s.w.(LevelWriter); ok {
+		return lw.WriteLevel(logLevel, p)
+	}
+	return s.w.Write(p)
+}
+
+// set changes the underlying writer and returns the replaced one
+func (s *swapWriter) set(w io.Writer) io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replaced := s.w
+	s.w = w
+	return replaced
+}
@@ -0,0 +1,91 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileWriter(t *testing.T) {
+	dir := t.TempDir()
+	logWriter, err := NewFileWriter("testService", FileConfiguration{StorageDir: dir})
+	require.NoError(t, err)
+
+	logger := InitLogger("testService", InfoLog, logWriter)
+	expectedLogMsg := "test file log"
+	logger.Info(expectedLogMsg)
+
+	contents, err := os.ReadFile(filepath.Join(dir, "testService-"+defaultFileConfig.FileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), expectedLogMsg)
+}
+
+func TestNewFileWriterNotWritable(t *testing.T) {
+	dir := t.TempDir()
+	// a regular file can't be used as the storage directory
+	filePath := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(filePath, nil, 0644))
+
+	_, err := NewFileWriter("testService", FileConfiguration{StorageDir: filePath})
+	assert.Error(t, err)
+}
+
+func TestSetLogWriter(t *testing.T) {
+	buf1 := &bytes.Buffer{}
+	buf2 := &bytes.Buffer{}
+	logger := InitLogger("testService", InfoLog, buf1)
+
+	err := SetLogWriter(logger, buf2)
+	require.NoError(t, err)
+	logger.Info("test info log")
+	assert.Empty(t, buf1.String())
+	assert.Contains(t, buf2.String(), "test info log")
+
+	// the writer is kept when the log format is changed
+	require.NoError(t, logger.(FormatLogger).SetLogFormat(JSONFormat))
+	logger.Info("test json log")
+	assert.Empty(t, buf1.String())
+	assert.Contains(t, buf2.String(), "test json log")
+
+	err = SetLogWriter(NewNopeLogger(), buf2)
+	assert.Error(t, err)
+}
+
+type testFileCloser struct {
+	closed int
+}
+
+func (c *testFileCloser) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestSetLogWriterClosesFileWriter(t *testing.T) {
+	closer1 := &testFileCloser{}
+	fileWriter1 := &fileWriter{Writer: &bytes.Buffer{}, file: closer1}
+	closer2 := &testFileCloser{}
+	fileWriter2 := &fileWriter{Writer: &bytes.Buffer{}, file: closer2}
+	logger := InitLogger("testService", InfoLog, fileWriter1)
+
+	// setting the same writer again keeps it open
+	require.NoError(t, SetLogWriter(logger, fileWriter1))
+	assert.Zero(t, closer1.closed)
+
+	require.NoError(t, SetLogWriter(logger, fileWriter2))
+	assert.Equal(t, 1, closer1.closed)
+
+	// the writers which aren't created by NewFileWriter are kept open
+	require.NoError(t, SetLogWriter(logger, os.Stdout))
+	assert.Equal(t, 1, closer2.closed)
+	require.NoError(t, SetLogWriter(logger, &bytes.Buffer{}))
+	_, err := os.Stdout.Stat()
+	assert.NoError(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	stdLog "log"
 	"os"
+	"strings"
 
 	"github.com/go-kit/log"
 )
@@ -22,6 +23,15 @@ const (
 	ErrorLog = "ERROR"
 )
 
+// These constants identify the supported log output formats.
+const (
+	LogfmtFormat = "logfmt"
+	JSONFormat   = "json"
+)
+
+// levelKey is the key of the log level in the log entries
+const levelKey = "level"
+
 // Logger defines the interface for logging operations.
 type Logger interface {
 	// SetLogLevel sets minimum severity log level. If a logging method is called with a lower level of severity than
@@ -49,33 +59,69 @@ type Logger interface {
 	Tracef(msg string, args ...any)
 	// Warnf logs a formatted message at the WARN severity level
 	Warnf(msg string, args ...any)
+	// With returns a child Logger which adds the given key/value pairs to every log entry. The child Logger
+	// shares the log level and format settings with its parent.
+	With(keyvals ...any) Logger
+}
+
+// FormatLogger is an optional interface of Logger whose output format of the log entries can be changed at runtime,
+// which is implemented by the Logger created by InitLogger. The callers should check for it with a type assertion.
+type FormatLogger interface {
+	Logger
+	// SetLogFormat sets the output format of the log entries, either logfmt or json.
+	SetLogFormat(logFormat string) error
+	// LogFormat returns the current log format setting
+	LogFormat() string
 }
 
 type logger struct {
 	owningServiceName string
 	logLevel          *string
+	logFormat         *string
+	logWriter         *swapWriter
+	formatLogger      *log.SwapLogger
 	rootLogger        log.Logger
 	levelLoggers      map[string]log.Logger
+	// component is the name of the component if this is a component logger
+	component       string
+	componentLevels *componentLevels
+	// sampler samples the repeated log messages if this is a sampled logger
+	sampler *sampler
 }
 
-// InitLogger creates an instance of Logger
+// InitLogger creates an instance of Logger which writes log entries in logfmt format
 func InitLogger(owningServiceName string, logLevel string, logWriter io.Writer) Logger {
+	return InitLoggerWithFormat(owningServiceName, logLevel, LogfmtFormat, logWriter)
+}
+
+// InitLoggerWithFormat creates an instance of Logger which writes log entries in the given format.
+// The logfmt format will be used if the given format is invalid.
+func InitLoggerWithFormat(owningServiceName string, logLevel string, logFormat string, logWriter io.Writer) Logger {
 	if !isValidLogLevel(logLevel) {
 		logLevel = InfoLog
 	}
+	logFormat = strings.ToLower(logFormat)
+	if !isValidLogFormat(logFormat) {
+		logFormat = LogfmtFormat
+	}
 
 	// Set up logger
 	l := logger{
 		owningServiceName: owningServiceName,
 		logLevel:          &logLevel,
+		logFormat:         &logFormat,
+		componentLevels:   newComponentLevels(),
 	}
 
 	if logWriter == nil {
 		logWriter = os.Stdout
 	}
-	l.rootLogger = log.NewLogfmtLogger(logWriter)
+	// The log writer and the format logger can be swapped at runtime when they are changed
+	l.logWriter = newSwapWriter(logWriter)
+	l.formatLogger = &log.SwapLogger{}
+	l.formatLogger.Swap(newFormatLogger(logFormat, l.logWriter))
 	l.rootLogger = log.WithPrefix(
-		l.rootLogger,
+		recordLogger{next: l.formatLogger},
 		"ts",
 		log.DefaultTimestamp,
 		"app",
@@ -87,7 +133,7 @@ func InitLogger(owningServiceName string, logLevel string, logWriter io.Writer)
 	l.levelLoggers = map[string]log.Logger{}
 
 	for _, logLevel := range logLevels() {
-		l.levelLoggers[logLevel] = log.WithPrefix(l.rootLogger, "level", logLevel)
+		l.levelLoggers[logLevel] = log.WithPrefix(l.rootLogger, levelKey, logLevel)
 	}
 
 	return l
@@ -112,17 +158,55 @@ func isValidLogLevel(l string) bool {
 	return false
 }
 
-func (l logger) log(logLevel string, formatted bool, msg string, args ...any) {
+func isValidLogFormat(f string) bool {
+	return f == LogfmtFormat || f == JSONFormat
+}
+
+// newFormatLogger creates the underlying go-kit logger which encodes the log entries in the given format, and passes
+// the level of each entry to the log writer
+func newFormatLogger(logFormat string, logWriter *swapWriter) log.Logger {
+	return log.LoggerFunc(func(keyvals ...any) error {
+		w := levelEntryWriter{writer: logWriter, logLevel: levelOf(keyvals)}
+		if logFormat == JSONFormat {
+			return log.NewJSONLogger(w).Log(keyvals...)
+		}
+		return log.NewLogfmtLogger(w).Log(keyvals...)
+	})
+}
+
+// levelOf returns the level of a log entry, which is the first level key bound by the level loggers, so that the
+// level key/value pairs given by the callers are ignored
+func levelOf(keyvals []any) string {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == levelKey {
+			logLevel, _ := keyvals[i+1].(string)
+			return logLevel
+		}
+	}
+	return ""
+}
+
+// enabled returns true if the message at the given level passes the minimum log level and the sampling
+func (l logger) enabled(logLevel string, msg string) bool {
 	// Check minimum log level
+	minLogLevel := l.LogLevel()
 	for _, name := range logLevels() {
-		if name == *l.logLevel {
+		if name == minLogLevel {
 			break
 		}
 		if name == logLevel {
-			return
+			return false
 		}
 	}
 
+	return l.sampler == nil || l.sampler.allow(logLevel, msg)
+}
+
+func (l logger) log(logLevel string, formatted bool, msg string, args ...any) {
+	if !l.enabled(logLevel, msg) {
+		return
+	}
+
 	if args == nil {
 		args = []any{"msg", msg}
 	} else if formatted {
@@ -147,6 +231,11 @@ func (l logger) log(logLevel string, formatted bool, msg string, args ...any) {
 
 func (l logger) SetLogLevel(logLevel string) error {
 	if isValidLogLevel(logLevel) {
+		// A component logger only changes the log level of its own component
+		if l.component != "" {
+			l.componentLevels.set(l.component, logLevel)
+			return nil
+		}
 		*l.logLevel = logLevel
 
 		return nil
@@ -156,12 +245,54 @@ func (l logger) SetLogLevel(logLevel string) error {
 }
 
 func (l logger) LogLevel() string {
+	if l.component != "" {
+		if logLevel, ok := l.componentLevels.get(l.component); ok {
+			return logLevel
+		}
+	}
 	if l.logLevel == nil {
 		return ""
 	}
 	return *l.logLevel
 }
 
+func (l logger) SetLogFormat(logFormat string) error {
+	logFormat = strings.ToLower(logFormat)
+	if !isValidLogFormat(logFormat) {
+		return fmt.Errorf("invalid log format `%s`", logFormat)
+	}
+
+	*l.logFormat = logFormat
+	l.formatLogger.Swap(newFormatLogger(logFormat, l.logWriter))
+
+	return nil
+}
+
+func (l logger) LogFormat() string {
+	if l.logFormat == nil {
+		return ""
+	}
+	return *l.logFormat
+}
+
+func (l logger) With(keyvals ...any) Logger {
+	if len(keyvals) == 0 {
+		return l
+	}
+	if len(keyvals)%2 == 1 {
+		// add an empty string to keep k/v pairs correct
+		keyvals = append(keyvals, "")
+	}
+
+	child := l
+	child.levelLoggers = make(map[string]log.Logger, len(l.levelLoggers))
+	for logLevel, levelLogger := range l.levelLoggers {
+		child.levelLoggers[logLevel] = log.With(levelLogger, keyvals...)
+	}
+
+	return child
+}
+
 func (l logger) Info(msg string, args ...any) {
 	l.log(InfoLog, false, msg, args...)
 }
@@ -6,11 +6,16 @@ package log
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsValidLogLevel(t *testing.T) {
@@ -263,3 +268,82 @@ func TestErrorf(t *testing.T) {
 		t.Errorf("Expected %s exists in the writer. Got: %s", fmt.Sprintf(expectedLogMsg, expectedStrVar), result)
 	}
 }
+
+func TestLogJSONFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLoggerWithFormat("testService", InfoLog, JSONFormat, buf)
+
+	expectedLogMsg := "test json log"
+	logger.Info(expectedLogMsg, "key", "value")
+
+	result := make(map[string]any)
+	err := json.Unmarshal(buf.Bytes(), &result)
+	require.NoError(t, err, "log entry should be a valid JSON object")
+	assert.Equal(t, "testService", result["app"])
+	assert.Equal(t, InfoLog, result["level"])
+	assert.Equal(t, expectedLogMsg, result["msg"])
+	assert.Equal(t, "value", result["key"])
+	assert.Contains(t, result, "ts")
+	assert.Contains(t, result, "source")
+}
+
+func TestSetLogFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger, ok := InitLogger("testService", InfoLog, buf).(FormatLogger)
+	require.True(t, ok)
+	assert.Equal(t, LogfmtFormat, logger.LogFormat())
+
+	err := logger.SetLogFormat("JSON")
+	require.NoError(t, err)
+	assert.Equal(t, JSONFormat, logger.LogFormat())
+
+	logger.Info("test json log")
+	assert.True(t, json.Valid(buf.Bytes()), "log entry should be a valid JSON object. Got: %s", buf.String())
+
+	err = logger.SetLogFormat("xml")
+	assert.Error(t, err)
+	assert.Equal(t, JSONFormat, logger.LogFormat())
+}
+
+func TestInitLoggerWithInvalidFormat(t *testing.T) {
+	logger, ok := InitLoggerWithFormat("testService", InfoLog, "xml", nil).(FormatLogger)
+	require.True(t, ok)
+	assert.Equal(t, LogfmtFormat, logger.LogFormat())
+}
+
+func TestWith(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLogger("testService", InfoLog, buf)
+	child := logger.With("key1", "value1", "key2")
+
+	child.Info("test child log")
+	result := buf.String()
+	assert.Contains(t, result, "key1=value1")
+	assert.Contains(t, result, "key2=")
+	assert.Contains(t, result, "source=log_test.go")
+
+	buf.Reset()
+	logger.Info("test parent log")
+	assert.NotContains(t, buf.String(), "key1=value1", "parent logger should not carry the bound key/value pairs")
+
+	// child logger shares the log level with its parent
+	err := child.SetLogLevel(ErrorLog)
+	require.NoError(t, err)
+	assert.Equal(t, ErrorLog, logger.LogLevel())
+}
+
+func TestFromContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLogger("testService", InfoLog, buf)
+	expectedCorrelationId := "927e91d3-864c-4c26-852d-b68c39492d14"
+
+	// lint:ignore SA1029 legacy
+	// nolint:staticcheck // See golangci-lint #741
+	ctx := context.WithValue(context.Background(), common.CorrelationID, expectedCorrelationId)
+	FromContext(ctx, logger).Info("test correlated log")
+	assert.Contains(t, buf.String(), common.CorrelationID+"="+expectedCorrelationId)
+
+	buf.Reset()
+	FromContext(context.Background(), logger).Info("test uncorrelated log")
+	assert.NotContains(t, buf.String(), common.CorrelationID)
+}
@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	log "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Logger is an autogenerated mock type for the Logger type
 type Logger struct {
@@ -117,6 +120,24 @@ func (_m *Logger) Warnf(msg string, args ...any) {
 	_m.Called(_ca...)
 }
 
+// With provides a mock function with given fields: keyvals
+func (_m *Logger) With(keyvals ...any) log.Logger {
+	var _ca []any
+	_ca = append(_ca, keyvals...)
+	ret := _m.Called(_ca...)
+
+	var r0 log.Logger
+	if rf, ok := ret.Get(0).(func(...any) log.Logger); ok {
+		r0 = rf(keyvals...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(log.Logger)
+		}
+	}
+
+	return r0
+}
+
 // NewLogger creates a new instance of Logger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLogger(t interface {
@@ -63,3 +63,8 @@ func (lc NopeLogger) Tracef(_ string, _ ...any) {
 // Warnf simulates logging an formatted message at the WARN severity level
 func (lc NopeLogger) Warnf(_ string, _ ...any) {
 }
+
+// With returns the same NopeLogger as there is nothing to bind the key/value pairs to
+func (lc NopeLogger) With(_ ...any) Logger {
+	return lc
+}
@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultSamplingInterval = time.Second
+
+// SamplingConfig defines how repeated log messages are sampled by a sampled Logger.
+// Within each Interval, the first First occurrences of the same message at the same level are logged, and
+// thereafter only every Thereafter-th occurrence is logged. The number of suppressed messages is logged as a summary
+// at the end of the interval.
+type SamplingConfig struct {
+	// Interval is the period in which the occurrences of a message are counted. Default is 1 second if not set.
+	Interval time.Duration
+	// First is the number of occurrences of a message which are logged in each interval before sampling starts.
+	First int
+	// Thereafter is the sampling rate after the first occurrences, i.e. every Thereafter-th occurrence is logged.
+	// All the occurrences after the first ones are suppressed if it is not set.
+	Thereafter int
+}
+
+// SampledLogger is a Logger which supports sampling the repeated log messages.
+type SampledLogger interface {
+	Logger
+	// Sampled returns a child Logger which samples the repeated log messages according to the given SamplingConfig
+	Sampled(config SamplingConfig) Logger
+}
+
+// Sampled returns a child Logger of the given logger which samples the repeated log messages according to the
+// given SamplingConfig, so that hot paths do not flood the logs. The given logger is returned as is if it doesn't
+// implement SampledLogger.
+func Sampled(logger Logger, config SamplingConfig) Logger {
+	if sl, ok := logger.(SampledLogger); ok {
+		return sl.Sampled(config)
+	}
+	return logger
+}
+
+// Sampled returns a child Logger which samples the repeated log messages according to the given SamplingConfig
+func (l logger) Sampled(config SamplingConfig) Logger {
+	if config.Interval <= 0 {
+		config.Interval = defaultSamplingInterval
+	}
+
+	child := l
+	child.sampler = &sampler{
+		config:   config,
+		counters: make(map[samplingKey]*samplingCounter),
+		summarize: func(logLevel string, msg string, suppressed int) {
+			_ = l.levelLoggers[logLevel].Log(
+				"suppressed", suppressed,
+				"msg", fmt.Sprintf("suppressed %d repeated messages in %v: %s", suppressed, config.Interval, msg))
+		},
+	}
+	return child
+}
+
+type samplingKey struct {
+	logLevel string
+	msg      string
+}
+
+type samplingCounter struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// sampler counts the occurrences of the log messages and decides whether a message should be logged
+type sampler struct {
+	config    SamplingConfig
+	mu        sync.Mutex
+	counters  map[samplingKey]*samplingCounter
+	summarize func(logLevel string, msg string, suppressed int)
+}
+
+// allow returns true if the message at the given level should be logged
+func (s *sampler) allow(logLevel string, msg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	key := samplingKey{logLevel: logLevel, msg: msg}
+	c, ok := s.counters[key]
+	if ok && now.Sub(c.start) >= s.config.Interval {
+		// The interval has elapsed before the scheduled summary is logged
+		s.flush(key, c)
+		ok = false
+	}
+	if !ok {
+		c = &samplingCounter{start: now}
+		s.counters[key] = c
+		// Remove the counter at the end of the interval along with logging the summary of the suppressed messages,
+		// so that the counters of the messages which don't recur are not kept forever
+		time.AfterFunc(s.config.Interval, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			// Skip if the counter has already been flushed
+			if s.counters[key] == c {
+				s.flush(key, c)
+			}
+		})
+	}
+
+	c.count++
+	if c.count <= s.config.First {
+		return true
+	}
+	if s.config.Thereafter > 0 && (c.count-s.config.First)%s.config.Thereafter == 0 {
+		return true
+	}
+
+	c.suppressed++
+	return false
+}
+
+// flush logs the summary of the suppressed messages and removes the counter. The caller must hold the lock.
+func (s *sampler) flush(key samplingKey, c *samplingCounter) {
+	delete(s.counters, key)
+	if c.suppressed > 0 {
+		s.summarize(key.logLevel, key.msg, c.suppressed)
+	}
+}
@@ -0,0 +1,98 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer which is safe to be written by the summary timer while being read by the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSampled(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := InitLogger("testService", InfoLog, buf)
+	sampled := Sampled(logger, SamplingConfig{Interval: 100 * time.Millisecond, First: 2, Thereafter: 3})
+
+	for i := 0; i < 10; i++ {
+		sampled.Warnf("test warn log %d", i)
+	}
+	sampled.Info("test other log")
+
+	result := buf.String()
+	// the first 2 occurrences and then every 3rd occurrence (the 5th and the 8th) are logged
+	assert.Equal(t, 4, strings.Count(result, "test warn log"))
+	for _, i := range []string{"0", "1", "4", "7"} {
+		assert.Contains(t, result, "test warn log "+i)
+	}
+	assert.Contains(t, result, "test other log")
+
+	// the summary of the suppressed messages is logged at the end of the interval
+	assert.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "suppressed=6")
+	}, time.Second, 10*time.Millisecond)
+	assert.Contains(t, buf.String(), "level="+WarnLog+" ")
+
+	// the counter is reset in the next interval
+	sampled.Warnf("test warn log %d", 10)
+	assert.Contains(t, buf.String(), "test warn log 10")
+}
+
+func TestSampledSuppressAll(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := InitLogger("testService", InfoLog, buf)
+	sampled := Sampled(logger, SamplingConfig{Interval: time.Minute, First: 1})
+
+	for i := 0; i < 10; i++ {
+		sampled.Warn("test warn log")
+	}
+	assert.Equal(t, 1, strings.Count(buf.String(), "test warn log"))
+
+	// the parent logger is not sampled
+	logger.Warn("test warn log")
+	assert.Equal(t, 2, strings.Count(buf.String(), "test warn log"))
+}
+
+func TestSampledWithoutSampling(t *testing.T) {
+	logger := NewNopeLogger()
+	assert.Equal(t, logger, Sampled(logger, SamplingConfig{}))
+}
+
+func TestSampledEvictsCounters(t *testing.T) {
+	sampled := Sampled(InitLogger("testService", InfoLog, &syncBuffer{}), SamplingConfig{Interval: 50 * time.Millisecond, First: 2})
+
+	// the messages logged fewer times than First are never summarized, but their counters are still removed
+	for i := 0; i < 100; i++ {
+		sampled.Warn("test dynamic warn log " + strconv.Itoa(i))
+	}
+	s := sampled.(logger).sampler
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.counters) == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,333 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	kitlog "github.com/go-kit/log"
+)
+
+// SlogLevelTrace is the slog level corresponding to the TRACE log level, which is not pre-defined by slog
+const SlogLevelTrace = slog.Level(-8)
+
+// SlogLevelFromLogLevel returns the slog level for the given log level. slog.LevelInfo is returned for an invalid
+// log level.
+func SlogLevelFromLogLevel(logLevel string) slog.Level {
+	switch logLevel {
+	case TraceLog:
+		return SlogLevelTrace
+	case DebugLog:
+		return slog.LevelDebug
+	case WarnLog:
+		return slog.LevelWarn
+	case ErrorLog:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// LogLevelFromSlogLevel returns the log level for the given slog level, rounding down to the closest log level
+func LogLevelFromSlogLevel(level slog.Level) string {
+	switch {
+	case level < slog.LevelDebug:
+		return TraceLog
+	case level < slog.LevelInfo:
+		return DebugLog
+	case level < slog.LevelWarn:
+		return InfoLog
+	case level < slog.LevelError:
+		return WarnLog
+	default:
+		return ErrorLog
+	}
+}
+
+// slogHandler is a slog.Handler which writes the log records through a Logger
+type slogHandler struct {
+	logger Logger
+	// groupPrefix is the qualifier of the attribute keys added by WithGroup, e.g. "request."
+	groupPrefix string
+}
+
+// NewSlogHandler creates a slog.Handler which writes the log records through the given Logger, so that the libraries
+// accepting a *slog.Logger log through the same pipeline as the service. The slog levels are mapped to the
+// TRACE..ERROR log levels and the records below the log level set by Logger.SetLogLevel are discarded.
+func NewSlogHandler(logger Logger) slog.Handler {
+	return &slogHandler{logger: logger}
+}
+
+// Enabled reports whether the handler handles records at the given level
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= SlogLevelFromLogLevel(h.logger.LogLevel())
+}
+
+// Handle writes the record through the Logger at the corresponding log level
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	keyvals := make([]any, 0, record.NumAttrs()*2)
+	record.Attrs(func(attr slog.Attr) bool {
+		keyvals = appendAttr(keyvals, h.groupPrefix, attr)
+		return true
+	})
+
+	if rw, ok := h.logger.(recordWriter); ok {
+		return rw.writeRecord(record, keyvals)
+	}
+
+	// The Logger doesn't support writing the records, so the time and the source of the record are not kept
+	switch LogLevelFromSlogLevel(record.Level) {
+	case TraceLog:
+		h.logger.Trace(record.Message, keyvals...)
+	case DebugLog:
+		h.logger.Debug(record.Message, keyvals...)
+	case InfoLog:
+		h.logger.Info(record.Message, keyvals...)
+	case WarnLog:
+		h.logger.Warn(record.Message, keyvals...)
+	default:
+		h.logger.Error(record.Message, keyvals...)
+	}
+	return nil
+}
+
+// recordWriter is implemented by the Loggers which can write a slog.Record with its own time and source rather than
+// those of the slogHandler
+type recordWriter interface {
+	writeRecord(record slog.Record, keyvals []any) error
+}
+
+// recordKey is the key of the recordSource appended to the key/value pairs of a log entry written for a slog.Record
+type recordKey struct{}
+
+// recordSource is the time and the source of a slog.Record, which replace the values of the ts and source keys of
+// the log entry written for the record
+type recordSource struct {
+	ts     string
+	source string
+}
+
+// recordLogger is the underlying go-kit logger of a Logger, which replaces the values of the ts and source keys bound
+// by the Logger with the recordSource if the log entry is written for a slog.Record
+type recordLogger struct {
+	next kitlog.Logger
+}
+
+func (r recordLogger) Log(keyvals ...any) error {
+	n := len(keyvals)
+	if n < 2 || keyvals[n-2] != (recordKey{}) {
+		return r.next.Log(keyvals...)
+	}
+	rs, _ := keyvals[n-1].(recordSource)
+	keyvals = keyvals[:n-2]
+
+	// Only the first ts and source keys are bound by the Logger, and the others are from the record
+	tsReplaced, sourceReplaced := false, false
+	for i := 0; i+1 < len(keyvals) && !(tsReplaced && sourceReplaced); i += 2 {
+		switch {
+		case keyvals[i] == "ts" && !tsReplaced:
+			keyvals[i+1] = rs.ts
+			tsReplaced = true
+		case keyvals[i] == "source" && !sourceReplaced:
+			keyvals[i+1] = rs.source
+			sourceReplaced = true
+		}
+	}
+	return r.next.Log(keyvals...)
+}
+
+// writeRecord writes the record through the go-kit logger with the time and the source of the record
+func (l logger) writeRecord(record slog.Record, keyvals []any) error {
+	logLevel := LogLevelFromSlogLevel(record.Level)
+	if !l.enabled(logLevel, record.Message) {
+		return nil
+	}
+
+	if len(keyvals) == 0 || record.Message != "" {
+		keyvals = append(keyvals, "msg", record.Message)
+	}
+	rs := recordSource{ts: record.Time.Format(time.RFC3339Nano)}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		// Format the source in the same way as the go-kit Caller
+		rs.source = frame.File[strings.LastIndexByte(frame.File, '/')+1:] + ":" + strconv.Itoa(frame.Line)
+	}
+	return l.levelLoggers[logLevel].Log(append(keyvals, recordKey{}, rs)...)
+}
+
+// WithAttrs returns a new handler whose Logger binds the given attributes
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	keyvals := make([]any, 0, len(attrs)*2)
+	for _, attr := range attrs {
+		keyvals = appendAttr(keyvals, h.groupPrefix, attr)
+	}
+	return &slogHandler{logger: h.logger.With(keyvals...), groupPrefix: h.groupPrefix}
+}
+
+// WithGroup returns a new handler which qualifies the keys of the subsequent attributes with the given group name
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{logger: h.logger, groupPrefix: h.groupPrefix + name + "."}
+}
+
+// appendAttr appends the key/value pair of the attribute to keyvals, flattening the group attributes
+func appendAttr(keyvals []any, groupPrefix string, attr slog.Attr) []any {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return keyvals
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			groupPrefix = groupPrefix + attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			keyvals = appendAttr(keyvals, groupPrefix, groupAttr)
+		}
+		return keyvals
+	}
+
+	return append(keyvals, groupPrefix+attr.Key, attr.Value.Any())
+}
+
+// slogLogger is a Logger which writes the log entries through a slog.Handler
+type slogLogger struct {
+	handler  slog.Handler
+	logLevel *slog.LevelVar
+}
+
+// NewSlogLogger creates a Logger which writes the log entries through the given slog.Handler, e.g. to share the
+// handler infrastructure with the auditlog package. The TRACE..ERROR log levels are mapped to the slog levels, and
+// the INFO log level is used if the given log level is invalid. The output format is determined by the handler.
+func NewSlogLogger(handler slog.Handler, logLevel string) Logger {
+	if !isValidLogLevel(logLevel) {
+		logLevel = InfoLog
+	}
+
+	l := slogLogger{
+		handler:  handler,
+		logLevel: new(slog.LevelVar),
+	}
+	l.logLevel.Set(SlogLevelFromLogLevel(logLevel))
+	return l
+}
+
+func (l slogLogger) log(level slog.Level, formatted bool, msg string, args ...any) {
+	ctx := context.Background()
+	if level < l.logLevel.Level() || !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip runtime.Callers, this function and the Logger method to record the source of the caller
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+
+	if formatted {
+		msg = fmt.Sprintf(msg, args...)
+		args = nil
+	}
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	record.Add(args...)
+
+	_ = l.handler.Handle(ctx, record)
+}
+
+// writeRecord writes the record through the slog.Handler with the time and the source of the record
+func (l slogLogger) writeRecord(record slog.Record, keyvals []any) error {
+	ctx := context.Background()
+	if record.Level < l.logLevel.Level() || !l.handler.Enabled(ctx, record.Level) {
+		return nil
+	}
+
+	r := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	r.Add(keyvals...)
+	return l.handler.Handle(ctx, r)
+}
+
+func (l slogLogger) SetLogLevel(logLevel string) error {
+	if isValidLogLevel(logLevel) {
+		l.logLevel.Set(SlogLevelFromLogLevel(logLevel))
+
+		return nil
+	}
+
+	return fmt.Errorf("invalid log level `%s`", logLevel)
+}
+
+func (l slogLogger) LogLevel() string {
+	return LogLevelFromSlogLevel(l.logLevel.Level())
+}
+
+func (l slogLogger) With(keyvals ...any) Logger {
+	if len(keyvals) == 0 {
+		return l
+	}
+
+	// Build the attributes in the same way as slog.Logger.With
+	record := slog.Record{}
+	record.Add(keyvals...)
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+
+	return slogLogger{
+		handler:  l.handler.WithAttrs(attrs),
+		logLevel: l.logLevel,
+	}
+}
+
+func (l slogLogger) Info(msg string, args ...any) {
+	l.log(slog.LevelInfo, false, msg, args...)
+}
+
+func (l slogLogger) Trace(msg string, args ...any) {
+	l.log(SlogLevelTrace, false, msg, args...)
+}
+
+func (l slogLogger) Debug(msg string, args ...any) {
+	l.log(slog.LevelDebug, false, msg, args...)
+}
+
+func (l slogLogger) Warn(msg string, args ...any) {
+	l.log(slog.LevelWarn, false, msg, args...)
+}
+
+func (l slogLogger) Error(msg string, args ...any) {
+	l.log(slog.LevelError, false, msg, args...)
+}
+
+func (l slogLogger) Infof(msg string, args ...any) {
+	l.log(slog.LevelInfo, true, msg, args...)
+}
+
+func (l slogLogger) Tracef(msg string, args ...any) {
+	l.log(SlogLevelTrace, true, msg, args...)
+}
+
+func (l slogLogger) Debugf(msg string, args ...any) {
+	l.log(slog.LevelDebug, true, msg, args...)
+}
+
+func (l slogLogger) Warnf(msg string, args ...any) {
+	l.log(slog.LevelWarn, true, msg, args...)
+}
+
+func (l slogLogger) Errorf(msg string, args ...any) {
+	l.log(slog.LevelError, true, msg, args...)
+}
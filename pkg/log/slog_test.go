@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogLevelMapping(t *testing.T) {
+	for _, logLevel := range logLevels() {
+		t.Run(logLevel, func(t *testing.T) {
+			assert.Equal(t, logLevel, LogLevelFromSlogLevel(SlogLevelFromLogLevel(logLevel)))
+		})
+	}
+	assert.Equal(t, TraceLog, LogLevelFromSlogLevel(slog.Level(-12)))
+	assert.Equal(t, InfoLog, LogLevelFromSlogLevel(slog.Level(2)))
+	assert.Equal(t, ErrorLog, LogLevelFromSlogLevel(slog.Level(12)))
+}
+
+func TestSlogHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLogger("testService", InfoLog, buf)
+	slogger := slog.New(NewSlogHandler(logger))
+
+	slogger.Debug("test debug log")
+	assert.Empty(t, buf.String(), "records below the log level should be discarded")
+
+	slogger.With("key1", "value1").WithGroup("request").Warn("test warn log", "method", "GET", slog.Group("user", "name", "admin"))
+	result := buf.String()
+	assert.Contains(t, result, "level="+WarnLog)
+	assert.Contains(t, result, "msg=\"test warn log\"")
+	assert.Contains(t, result, "key1=value1")
+	assert.Contains(t, result, "request.method=GET")
+	assert.Contains(t, result, "request.user.name=admin")
+
+	// the handler honours the log level changed at runtime
+	require.NoError(t, logger.SetLogLevel(TraceLog))
+	buf.Reset()
+	slogger.Log(context.Background(), SlogLevelTrace, "test trace log")
+	assert.Contains(t, buf.String(), "level="+TraceLog)
+}
+
+func TestSlogHandlerRecordTimeAndSource(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := InitLoggerWithFormat("testService", InfoLog, JSONFormat, buf)
+	handler := NewSlogHandler(logger)
+
+	// the record is logged with its own time and source rather than those of the handler
+	recordTime := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	_, _, line, _ := runtime.Caller(0)
+	record := slog.NewRecord(recordTime, slog.LevelInfo, "test record log", pcs[0])
+	require.NoError(t, handler.Handle(context.Background(), record))
+
+	result := make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, recordTime.Format(time.RFC3339Nano), result["ts"])
+	assert.Equal(t, "slog_test.go:"+strconv.Itoa(line-1), result["source"])
+	assert.Equal(t, "test record log", result["msg"])
+
+	// the source is logged at the caller of the *slog.Logger
+	buf.Reset()
+	slog.New(NewSlogHandler(logger)).Info("test caller log")
+	_, _, line, _ = runtime.Caller(0)
+	result = make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "slog_test.go:"+strconv.Itoa(line-1), result["source"])
+}
+
+func TestSlogLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewSlogLogger(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: SlogLevelTrace, AddSource: true}), DebugLog)
+	assert.Equal(t, DebugLog, logger.LogLevel())
+
+	logger.Trace("test trace log")
+	assert.Empty(t, buf.String(), "entries below the log level should be discarded")
+
+	logger.With("key1", "value1").Infof("test info log with msg is %s", "abc123")
+	result := make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "INFO", result[slog.LevelKey])
+	assert.Equal(t, "test info log with msg is abc123", result[slog.MessageKey])
+	assert.Equal(t, "value1", result["key1"])
+	source, ok := result[slog.SourceKey].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, source["file"], "slog_test.go")
+
+	require.NoError(t, logger.SetLogLevel(ErrorLog))
+	assert.Equal(t, ErrorLog, logger.LogLevel())
+	assert.Error(t, logger.SetLogLevel("INF"))
+	// the output format is determined by the slog.Handler
+	_, ok = logger.(FormatLogger)
+	assert.False(t, ok)
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package syslog
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+// Constants of the supported network of the syslog collector
+const (
+	NetworkUDP    = "udp"
+	NetworkTCP    = "tcp"
+	NetworkTCPTLS = "tcp+tls"
+)
+
+// Facility is the syslog facility defined in RFC 5424
+type Facility int
+
+// Constant Facility values which are commonly used by the edge services
+const (
+	FacilityUser     Facility = 1
+	FacilityAuth     Facility = 4
+	FacilityAuthPriv Facility = 10
+	FacilityLocal0   Facility = 16
+	FacilityLocal1   Facility = 17
+	FacilityLocal2   Facility = 18
+	FacilityLocal3   Facility = 19
+	FacilityLocal4   Facility = 20
+	FacilityLocal5   Facility = 21
+	FacilityLocal6   Facility = 22
+	FacilityLocal7   Facility = 23
+)
+
+// Configuration defines the syslog collector and the framing of the messages
+type Configuration struct {
+	// Network is the network of the syslog collector, which is one of udp, tcp or tcp+tls. It defaults to udp.
+	Network string
+
+	// Address is the address of the syslog collector in the form of host:port.
+	Address string
+
+	// TLSConfig is the TLS configuration used when the Network is tcp+tls.
+	TLSConfig *tls.Config
+
+	// Facility is the syslog facility of the messages. It defaults to local0.
+	Facility Facility
+
+	// AppName is the APP-NAME field of the messages, which is typically the service name.
+	AppName string
+
+	// Hostname is the HOSTNAME field of the messages. It defaults to the hostname of the machine.
+	Hostname string
+
+	// BufferSize is the maximum number of messages buffered while the collector is unavailable. It defaults to 1000.
+	// The newest messages are dropped when the buffer is full.
+	BufferSize int
+
+	// ReconnectInterval is the interval between the attempts to reconnect to the collector. It defaults to 5 seconds.
+	ReconnectInterval time.Duration
+
+	// WriteTimeout is the timeout of sending a message to the collector. It defaults to 5 seconds.
+	WriteTimeout time.Duration
+}
+
+var defaultConfig = Configuration{
+	Network:           NetworkUDP,
+	Facility:          FacilityLocal0,
+	BufferSize:        1000,
+	ReconnectInterval: 5 * time.Second,
+	WriteTimeout:      5 * time.Second,
+}
+
+func (c *Configuration) setDefault() {
+	if c.Network == "" {
+		c.Network = defaultConfig.Network
+	}
+	if c.Facility == 0 {
+		c.Facility = defaultConfig.Facility
+	}
+	if c.BufferSize == 0 {
+		c.BufferSize = defaultConfig.BufferSize
+	}
+	if c.ReconnectInterval == 0 {
+		c.ReconnectInterval = defaultConfig.ReconnectInterval
+	}
+	if c.WriteTimeout == 0 {
+		c.WriteTimeout = defaultConfig.WriteTimeout
+	}
+}
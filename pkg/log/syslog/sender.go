@@ -0,0 +1,171 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package syslog
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sender sends each log entry as an RFC 5424 message to a remote syslog collector synchronously and reports the
+// failure of the delivery, e.g. for the callers buffering the undeliverable entries by themselves. The connection is
+// re-established on the next send once it is lost. Note that the delivery over UDP can't be confirmed, so only the
+// failures of the local network stack are reported.
+type Sender struct {
+	config   Configuration
+	hostname string
+	procID   string
+	conn     net.Conn
+	mu       sync.Mutex
+}
+
+// NewSender creates a Sender according to the given configuration, which connects to the collector on the first send.
+// An error is returned if the configuration is invalid.
+func NewSender(config Configuration) (*Sender, error) {
+	config.setDefault()
+	switch config.Network {
+	case NetworkUDP, NetworkTCP, NetworkTCPTLS:
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %s", config.Network)
+	}
+	if config.Address == "" {
+		return nil, fmt.Errorf("syslog address is not specified")
+	}
+	if config.Facility < 0 || config.Facility > FacilityLocal7 {
+		return nil, fmt.Errorf("invalid syslog facility %d", config.Facility)
+	}
+
+	hostname := config.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+
+	return &Sender{
+		config:   config,
+		hostname: headerField(hostname, maxHostnameLength),
+		procID:   strconv.Itoa(os.Getpid()),
+	}, nil
+}
+
+// Send formats the entry as an RFC 5424 message of the given severity and sends it to the collector within the write
+// timeout. An error is returned if the collector can't be reached or the message fails to be sent.
+func (s *Sender) Send(severity Severity, entry []byte) error {
+	return s.send(s.format(time.Now(), severity, entry))
+}
+
+// Close closes the connection to the collector
+func (s *Sender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format builds the RFC 5424 message of the given log entry
+func (s *Sender) format(ts time.Time, severity Severity, entry []byte) []byte {
+	appName := headerField(s.config.AppName, maxAppNameLength)
+	buf := bytes.NewBuffer(make([]byte, 0, len(entry)+128))
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	fmt.Fprintf(buf, "<%d>1 %s %s %s %s %s %s ",
+		int(s.config.Facility)*8+int(severity),
+		ts.Format(time.RFC3339Nano),
+		s.hostname,
+		appName,
+		s.procID,
+		nilValue,
+		nilValue,
+	)
+	buf.Write(bytes.TrimRight(entry, "\r\n"))
+	return buf.Bytes()
+}
+
+// send writes a formatted message to the collector. If the existing connection turns out to be broken, the message is
+// resent right away over a new connection.
+func (s *Sender) send(msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		if err := s.write(msg); err == nil {
+			return nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+
+	conn, err := s.dial()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the syslog collector %s: %w", s.config.Address, err)
+	}
+	s.conn = conn
+	if err := s.write(msg); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("failed to send the message to the syslog collector %s: %w", s.config.Address, err)
+	}
+	return nil
+}
+
+// connected returns whether there is a connection to the collector
+func (s *Sender) connected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn != nil
+}
+
+// dial connects to the collector with the configured network
+func (s *Sender) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.config.WriteTimeout}
+	switch s.config.Network {
+	case NetworkTCPTLS:
+		return tls.DialWithDialer(dialer, "tcp", s.config.Address, s.config.TLSConfig)
+	default:
+		return dialer.Dial(s.config.Network, s.config.Address)
+	}
+}
+
+// write writes a message to the connection. Each UDP datagram carries one message, while the messages sent over TCP
+// are framed with octet counting as defined in RFC 6587 and RFC 5425.
+func (s *Sender) write(msg []byte) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.config.WriteTimeout)); err != nil {
+		return err
+	}
+	if s.config.Network == NetworkUDP {
+		_, err := s.conn.Write(msg)
+		return err
+	}
+	frame := make([]byte, 0, len(msg)+8)
+	frame = strconv.AppendInt(frame, int64(len(msg)), 10)
+	frame = append(frame, ' ')
+	frame = append(frame, msg...)
+	_, err := s.conn.Write(frame)
+	return err
+}
+
+// headerField returns the value as an RFC 5424 header field, which must be printable US-ASCII without spaces
+func headerField(value string, maxLength int) string {
+	b := make([]byte, 0, len(value))
+	for i := 0; i < len(value) && len(b) < maxLength; i++ {
+		if c := value[i]; c > 32 && c < 127 {
+			b = append(b, c)
+		}
+	}
+	if len(b) == 0 {
+		return nilValue
+	}
+	return string(b)
+}
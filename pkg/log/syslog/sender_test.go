@@ -0,0 +1,44 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package syslog
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderReconnect(t *testing.T) {
+	// Reserve a free port and release it, so that the collector is down when the first entry is sent
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	s, err := NewSender(Configuration{Network: NetworkTCP, Address: addr, AppName: testAppName, WriteTimeout: time.Second})
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Error(t, s.Send(SeverityError, []byte("level=ERROR msg=lost\n")))
+
+	// The next send connects to the collector once it is up
+	l, err = net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer l.Close()
+	require.NoError(t, s.Send(SeverityError, []byte("level=ERROR msg=delivered\n")))
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	msg := readOctetCountedFrame(t, bufio.NewReader(conn))
+	// local0 (16) * 8 + error (3)
+	assert.True(t, strings.HasPrefix(msg, "<131>1 "), msg)
+	assert.Contains(t, msg, "msg=delivered")
+}
@@ -0,0 +1,51 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package syslog
+
+import (
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+)
+
+// Severity is the syslog severity defined in RFC 5424
+type Severity int
+
+// Constant Severity values defined in RFC 5424
+const (
+	SeverityEmergency Severity = iota
+	SeverityAlert
+	SeverityCritical
+	SeverityError
+	SeverityWarning
+	SeverityNotice
+	SeverityInformational
+	SeverityDebug
+)
+
+// SeverityFromLogLevel returns the syslog severity of the given service log level
+func SeverityFromLogLevel(logLevel string) Severity {
+	switch logLevel {
+	case log.TraceLog, log.DebugLog:
+		return SeverityDebug
+	case log.WarnLog:
+		return SeverityWarning
+	case log.ErrorLog:
+		return SeverityError
+	default:
+		return SeverityInformational
+	}
+}
+
+// SeverityFromAuditSeverity returns the syslog severity of the given audit log severity
+func SeverityFromAuditSeverity(severity auditlog.Severity) Severity {
+	switch severity {
+	case auditlog.SeverityCritical:
+		return SeverityCritical
+	case auditlog.SeverityMinor:
+		return SeverityInformational
+	default:
+		return SeverityNotice
+	}
+}
@@ -0,0 +1,158 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package syslog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+)
+
+const (
+	// nilValue is the NILVALUE of RFC 5424 which is used when a header field is unknown
+	nilValue = "-"
+	// maxHostnameLength and maxAppNameLength are the maximum lengths of the HOSTNAME and APP-NAME header fields
+	maxHostnameLength = 255
+	maxAppNameLength  = 48
+)
+
+// Writer is an io.Writer which sends each written log entry as an RFC 5424 message to a remote syslog collector.
+// It can be used as the logWriter of both log.InitLogger and auditlog.InitLogger.
+//
+// Writes never block on the network: messages are buffered and sent by a background goroutine, which reconnects to
+// the collector whenever the connection is lost. The newest messages are dropped if the buffer is full. Use Sender
+// instead if the failures of the delivery must be reported.
+type Writer struct {
+	sender   *Sender
+	config   Configuration
+	messages chan []byte
+	dropped  atomic.Uint64
+	done     chan struct{}
+	stopped  chan struct{}
+	once     sync.Once
+}
+
+// NewWriter creates a Writer according to the given configuration and starts sending messages to the collector in
+// background. An error is returned if the configuration is invalid; the collector being unreachable is not an error.
+func NewWriter(config Configuration) (*Writer, error) {
+	sender, err := NewSender(config)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{
+		sender:   sender,
+		config:   sender.config,
+		messages: make(chan []byte, sender.config.BufferSize),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go w.run()
+
+	return w, nil
+}
+
+// Write formats p as an RFC 5424 message of the informational severity and buffers it for sending. The loggers of the
+// log and auditlog packages write through WriteLevel and WriteSeverity instead, which carry the severity of the entry.
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.write(SeverityInformational, p)
+}
+
+// WriteLevel is the same as Write, except that the syslog severity is mapped from the given service log level. It
+// implements log.LevelWriter.
+func (w *Writer) WriteLevel(logLevel string, p []byte) (int, error) {
+	return w.write(SeverityFromLogLevel(logLevel), p)
+}
+
+// WriteSeverity is the same as Write, except that the syslog severity is mapped from the given audit log severity. It
+// implements auditlog.SeverityWriter.
+func (w *Writer) WriteSeverity(severity auditlog.Severity, p []byte) (int, error) {
+	return w.write(SeverityFromAuditSeverity(severity), p)
+}
+
+// write buffers the message of the given severity, or drops it if the buffer is full or the Writer is closed. The
+// message is never reported as an error, since the loggers treat the write errors as fatal.
+func (w *Writer) write(severity Severity, p []byte) (int, error) {
+	select {
+	case <-w.done:
+		w.dropped.Add(1)
+		return len(p), nil
+	default:
+	}
+
+	msg := w.sender.format(time.Now(), severity, p)
+	select {
+	case w.messages <- msg:
+	default:
+		w.dropped.Add(1)
+	}
+
+	return len(p), nil
+}
+
+// Dropped returns the number of messages dropped since the buffer was full or the Writer was closed
+func (w *Writer) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close stops the Writer after trying to send the buffered messages within the write timeout. The messages written
+// afterwards are dropped.
+func (w *Writer) Close() error {
+	w.once.Do(func() {
+		close(w.done)
+	})
+	<-w.stopped
+	return nil
+}
+
+// run sends the buffered messages to the collector until the Writer is closed
+func (w *Writer) run() {
+	defer close(w.stopped)
+	defer func() {
+		_ = w.sender.Close()
+	}()
+
+	var pending []byte
+	for {
+		if pending == nil {
+			select {
+			case pending = <-w.messages:
+			case <-w.done:
+				w.drain()
+				return
+			}
+		}
+
+		if err := w.sender.send(pending); err != nil {
+			// Keep the pending message and retry after the reconnect interval
+			select {
+			case <-time.After(w.config.ReconnectInterval):
+				continue
+			case <-w.done:
+				return
+			}
+		}
+		pending = nil
+	}
+}
+
+// drain tries to send the buffered messages with the existing connection when the Writer is closed
+func (w *Writer) drain() {
+	if !w.sender.connected() {
+		return
+	}
+	for {
+		select {
+		case msg := <-w.messages:
+			if err := w.sender.send(msg); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,189 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package syslog
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/auditlog"
+)
+
+const testAppName = "test-service"
+
+func TestNewWriterInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Configuration
+	}{
+		{"unsupported network", Configuration{Network: "http", Address: "localhost:514"}},
+		{"empty address", Configuration{Network: NetworkUDP}},
+		{"invalid facility", Configuration{Address: "localhost:514", Facility: 24}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWriter(tt.config)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWriterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w, err := NewWriter(Configuration{Network: NetworkUDP, Address: conn.LocalAddr().String(), AppName: testAppName, Hostname: "edge-node"})
+	require.NoError(t, err)
+	defer w.Close()
+
+	lc := log.InitLogger(testAppName, log.InfoLog, w)
+	lc.Error("something went wrong")
+
+	buf := make([]byte, 2048)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	msg := string(buf[:n])
+	// local0 (16) * 8 + error (3)
+	assert.True(t, strings.HasPrefix(msg, "<131>1 "), msg)
+	fields := strings.SplitN(msg, " ", 8)
+	require.Len(t, fields, 8)
+	assert.Equal(t, "edge-node", fields[2])
+	assert.Equal(t, testAppName, fields[3])
+	assert.Equal(t, w.sender.procID, fields[4])
+	assert.Equal(t, nilValue, fields[5])
+	assert.Equal(t, nilValue, fields[6])
+	assert.Contains(t, fields[7], `msg="something went wrong"`)
+	assert.False(t, strings.HasSuffix(msg, "\n"))
+}
+
+func TestWriterTCPReconnect(t *testing.T) {
+	// Reserve a free port and release it, so that the collector is down when the first entries are written
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	w, err := NewWriter(Configuration{
+		Network:           NetworkTCP,
+		Address:           addr,
+		Facility:          FacilityAuthPriv,
+		AppName:           testAppName,
+		ReconnectInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer w.Close()
+
+	auditLogger := auditlog.InitLogger(testAppName, auditlog.BaseCoverage, w, auditlog.Configuration{})
+	auditLogger.SetEnabled(true)
+	auditLogger.LogBase(auditlog.SeverityCritical, "admin", auditlog.ActionTypeDelete, "device deleted", nil)
+	auditLogger.LogBase(auditlog.SeverityMinor, "admin", auditlog.ActionTypeRead, "device read", nil)
+
+	// Start the collector after the entries are buffered
+	time.Sleep(100 * time.Millisecond)
+	l, err = net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer l.Close()
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	reader := bufio.NewReader(conn)
+
+	// authpriv (10) * 8 + critical (2)
+	msg := readOctetCountedFrame(t, reader)
+	assert.True(t, strings.HasPrefix(msg, "<82>1 "), msg)
+	assert.Contains(t, msg, `desc="device deleted"`)
+	// authpriv (10) * 8 + informational (6)
+	msg = readOctetCountedFrame(t, reader)
+	assert.True(t, strings.HasPrefix(msg, "<86>1 "), msg)
+	assert.Contains(t, msg, `desc="device read"`)
+}
+
+func TestWriterBufferFull(t *testing.T) {
+	w, err := NewWriter(Configuration{Network: NetworkTCP, Address: "127.0.0.1:1", BufferSize: 1, ReconnectInterval: time.Hour})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		n, err := w.Write([]byte("level=INFO msg=test\n"))
+		require.NoError(t, err)
+		assert.Equal(t, 20, n)
+	}
+	assert.NotZero(t, w.Dropped())
+
+	// The messages written after Close are dropped without an error
+	require.NoError(t, w.Close())
+	dropped := w.Dropped()
+	n, err := w.Write([]byte("level=INFO msg=test\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 20, n)
+	assert.Equal(t, dropped+1, w.Dropped())
+}
+
+func TestWriterSeverityFromLoggers(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w, err := NewWriter(Configuration{Network: NetworkUDP, Address: conn.LocalAddr().String(), AppName: testAppName})
+	require.NoError(t, err)
+	defer w.Close()
+
+	readMessage := func() string {
+		buf := make([]byte, 2048)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	// The severity is taken from the level of the entry rather than the key/value pairs given by the caller
+	for _, format := range []string{log.LogfmtFormat, log.JSONFormat} {
+		lc := log.InitLoggerWithFormat(testAppName, log.InfoLog, format, w)
+		lc.Info("severity=CRITICAL level=ERROR", "level", log.ErrorLog)
+		// local0 (16) * 8 + informational (6)
+		msg := readMessage()
+		assert.True(t, strings.HasPrefix(msg, "<134>1 "), msg)
+		lc.Warn("something went wrong")
+		// local0 (16) * 8 + warning (4)
+		msg = readMessage()
+		assert.True(t, strings.HasPrefix(msg, "<132>1 "), msg)
+	}
+
+	auditLogger := auditlog.InitLogger(testAppName, auditlog.BaseCoverage, w, auditlog.Configuration{HashChain: true})
+	auditLogger.SetEnabled(true)
+	auditLogger.LogBase(auditlog.SeverityMinor, "admin", auditlog.ActionTypeRead, "severity=CRITICAL", nil)
+	// local0 (16) * 8 + informational (6)
+	msg := readMessage()
+	assert.True(t, strings.HasPrefix(msg, "<134>1 "), msg)
+	auditLogger.LogBase(auditlog.SeverityCritical, "admin", auditlog.ActionTypeDelete, "device deleted", nil)
+	// local0 (16) * 8 + critical (2)
+	msg = readMessage()
+	assert.True(t, strings.HasPrefix(msg, "<130>1 "), msg)
+}
+
+// readOctetCountedFrame reads a message framed with octet counting
+func readOctetCountedFrame(t *testing.T, reader *bufio.Reader) string {
+	length, err := reader.ReadString(' ')
+	require.NoError(t, err)
+	n, err := strconv.Atoi(strings.TrimSpace(length))
+	require.NoError(t, err)
+	buf := make([]byte, n)
+	_, err = io.ReadFull(reader, buf)
+	require.NoError(t, err)
+	return string(buf)
+}
@@ -0,0 +1,76 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/validator"
+)
+
+// AuditLogSetting defines the runtime settings of the audit logger
+type AuditLogSetting struct {
+	Enabled       *bool  `json:"enabled,omitempty"`
+	CoverageLevel string `json:"coverageLevel,omitempty" validate:"omitempty,oneof=BASE ADVANCED FULL"`
+}
+
+// LogLevelRequest is the request DTO for changing the log level settings of the service at runtime
+type LogLevelRequest struct {
+	BaseRequest        `json:",inline"`
+	LogLevel           string            `json:"logLevel,omitempty" validate:"omitempty,oneof=TRACE DEBUG INFO WARN ERROR"`
+	ComponentLogLevels map[string]string `json:"componentLogLevels,omitempty" validate:"omitempty,dive,omitempty,oneof=TRACE DEBUG INFO WARN ERROR"`
+	AuditLog           *AuditLogSetting  `json:"auditLog,omitempty"`
+}
+
+// Validate satisfies the Validator interface
+func (lr *LogLevelRequest) Validate() error {
+	err := validator.Validate(lr)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the LogLevelRequest type
+func (lr *LogLevelRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		BaseRequest
+		LogLevel           string
+		ComponentLogLevels map[string]string
+		AuditLog           *AuditLogSetting
+	}
+
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewBaseError(errors.KindContractInvalid, "Failed to unmarshal LogLevelRequest body as JSON.", err)
+	}
+
+	*lr = LogLevelRequest(alias)
+
+	// validate LogLevelRequest DTO
+	if err := lr.Validate(); err != nil {
+		return errors.NewBaseError(errors.KindContractInvalid, "LogLevelRequest validation failed.", err)
+	}
+	return nil
+}
+
+// LogLevelResponse defines the current log level settings of the service
+type LogLevelResponse struct {
+	BaseResponse       `json:",inline"`
+	ServiceName        string            `json:"serviceName"`
+	LogLevel           string            `json:"logLevel"`
+	ComponentLogLevels map[string]string `json:"componentLogLevels,omitempty"`
+	AuditLog           *AuditLogSetting  `json:"auditLog,omitempty"`
+}
+
+// NewLogLevelResponse creates new LogLevelResponse with all fields set appropriately
+func NewLogLevelResponse(serviceName string, logLevel string, componentLogLevels map[string]string, auditLog *AuditLogSetting) LogLevelResponse {
+	return LogLevelResponse{
+		BaseResponse:       NewBaseResponse("", "", http.StatusOK),
+		ServiceName:        serviceName,
+		LogLevel:           logLevel,
+		ComponentLogLevels: componentLogLevels,
+		AuditLog:           auditLog,
+	}
+}
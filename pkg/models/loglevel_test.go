@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
+)
+
+func TestLogLevelRequest_Validate(t *testing.T) {
+	enabled := true
+	validRequest := LogLevelRequest{
+		BaseRequest:        BaseRequest{RequestId: TestUUID, Versionable: NewVersionable()},
+		LogLevel:           "DEBUG",
+		ComponentLogLevels: map[string]string{"sse": "TRACE", "secrets": ""},
+		AuditLog:           &AuditLogSetting{Enabled: &enabled, CoverageLevel: "FULL"},
+	}
+	emptyRequest := LogLevelRequest{BaseRequest: validRequest.BaseRequest}
+	invalidLogLevel := validRequest
+	invalidLogLevel.LogLevel = "INF"
+	invalidComponentLogLevel := validRequest
+	invalidComponentLogLevel.ComponentLogLevels = map[string]string{"sse": "VERBOSE"}
+	invalidCoverageLevel := validRequest
+	invalidCoverageLevel.AuditLog = &AuditLogSetting{CoverageLevel: "PARTIAL"}
+
+	tests := []struct {
+		Name          string
+		Request       LogLevelRequest
+		ErrorExpected bool
+	}{
+		{"valid", validRequest, false},
+		{"valid - no settings", emptyRequest, false},
+		{"invalid - log level", invalidLogLevel, true},
+		{"invalid - component log level", invalidComponentLogLevel, true},
+		{"invalid - audit log coverage level", invalidCoverageLevel, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := testCase.Request.Validate()
+			if testCase.ErrorExpected {
+				require.Error(t, err)
+				return // Test complete
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLogLevelRequest_UnmarshalJSON(t *testing.T) {
+	validRequest := LogLevelRequest{
+		BaseRequest: BaseRequest{RequestId: TestUUID, Versionable: NewVersionable()},
+		LogLevel:    "DEBUG",
+	}
+	resultTestBytes, _ := json.Marshal(validRequest)
+
+	tests := []struct {
+		Name          string
+		Expected      LogLevelRequest
+		Data          []byte
+		ErrorExpected bool
+		ErrorKind     errors.ErrKind
+	}{
+		{"unmarshal with success", validRequest, resultTestBytes, false, ""},
+		{"unmarshal invalid, empty data", LogLevelRequest{}, []byte{}, true, errors.KindContractInvalid},
+		{"unmarshal invalid, invalid log level", LogLevelRequest{}, []byte(`{"logLevel":"INF"}`), true, errors.KindContractInvalid},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			actual := LogLevelRequest{}
+			err := actual.UnmarshalJSON(testCase.Data)
+			if testCase.ErrorExpected {
+				require.Error(t, err)
+				require.Equal(t, testCase.ErrorKind, errors.Kind(err))
+				return // Test complete
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.Expected, actual, "Unmarshal did not result in expected LogLevelRequest.")
+		})
+	}
+}
+
+func TestNewLogLevelResponse(t *testing.T) {
+	componentLogLevels := map[string]string{"sse": "DEBUG"}
+	target := NewLogLevelResponse("testService", "INFO", componentLogLevels, nil)
+
+	assert.Equal(t, common.ApiVersion, target.ApiVersion)
+	assert.Equal(t, http.StatusOK, target.StatusCode)
+	assert.Equal(t, "testService", target.ServiceName)
+	assert.Equal(t, "INFO", target.LogLevel)
+	assert.Equal(t, componentLogLevels, target.ComponentLogLevels)
+	assert.Nil(t, target.AuditLog)
+}
@@ -0,0 +1,294 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package re_exec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+const (
+	// offsetFileSuffix is the suffix of the file recording the number of dequeued items in the queue file
+	offsetFileSuffix = ".offset"
+	// compactThreshold is the minimum number of dequeued items before the queue file is compacted
+	compactThreshold = 100
+	// maxItemSize is the maximum size of an encoded item in the queue file
+	maxItemSize = 4 * 1024 * 1024
+)
+
+// fileQueue is a queue persisted in a file, so that the items survive restarts of the service. The items are
+// appended to the file as JSON lines, and the number of the dequeued items is recorded in a separate offset file.
+// The queue file is compacted once most of its items have been dequeued.
+type fileQueue[T any] struct {
+	dic           *di.Container
+	ctx           context.Context
+	filePath      string
+	queueLimit    int
+	retryInterval time.Duration
+	file          *os.File
+	items         []T
+	// dequeued is the number of dequeued items which are still in the queue file
+	dequeued int
+	lock     sync.Mutex
+	// dropLogger is a sampled logger for the repetitive traces of dropping items
+	dropLogger log.Logger
+}
+
+// NewFileQueue is a factory method that returns an initialized ReExecQueue persisted in the given file. The items
+// left in the file by the previous run are loaded and retried. It has the same retry semantics as NewMemoryQueue,
+// and the items must be able to be encoded as JSON.
+func NewFileQueue[T any](dic *di.Container, ctx context.Context, filePath string, queueLimit int, retryInterval string, fun ReExecFunc[T]) (common.Queue[T], errors.Error) {
+	logger := container.LoggerFrom(dic.Get)
+
+	if queueLimit == 0 {
+		queueLimit = DefaultMaxQueueLimit
+	}
+
+	interval := parseRetryInterval(logger, retryInterval)
+
+	q := &fileQueue[T]{
+		dic:           dic,
+		ctx:           ctx,
+		filePath:      filePath,
+		queueLimit:    queueLimit,
+		retryInterval: interval,
+		lock:          sync.Mutex{},
+		dropLogger:    log.Sampled(logger, dropLogSampling),
+	}
+	rewrite, err := q.load()
+	if err != nil {
+		return nil, errors.NewBaseError(errors.KindIOError, fmt.Sprintf("Failed to load the queue file '%s'", filePath), err)
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.NewBaseError(errors.KindIOError, fmt.Sprintf("Failed to open the queue file '%s'", filePath), err)
+	}
+	q.file = file
+	if rewrite {
+		// The lines which can't be decoded are removed before any item is appended to the queue file
+		if err := q.compact(); err != nil {
+			_ = q.file.Close()
+			return nil, errors.NewBaseError(errors.KindIOError, fmt.Sprintf("Failed to rewrite the queue file '%s'", filePath), err)
+		}
+	}
+
+	logger.Debugf("Start FileQueue '%s' with '%d' items, QueueLimit '%d' and RetryInterval '%s'", filePath, len(q.items), queueLimit, interval)
+	go func() {
+		reExecLoop(q.ctx, q.dic, q, q.retryInterval, fun)
+		q.close()
+	}()
+
+	return q, nil
+}
+
+// Enqueue method that adds a new item to the queue
+func (q *fileQueue[T]) Enqueue(item T) errors.Error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.items) >= q.queueLimit {
+		q.dropLogger.Tracef("Exceeded queue limit, drop the item: %v", item)
+		return errors.NewBaseError(errors.KindLimitExceeded, "Exceeded queue limit, drop the item", nil)
+	}
+	if q.file == nil {
+		return errors.NewBaseError(errors.KindServiceUnavailable, "Queue is closed, drop the item", nil)
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errors.NewBaseError(errors.KindContractInvalid, "Failed to encode the item", err)
+	}
+	if _, err := q.file.Write(append(data, '\n')); err != nil {
+		return errors.NewBaseError(errors.KindIOError, "Failed to write the item to the queue file", err)
+	}
+	q.items = append(q.items, item)
+
+	return nil
+}
+
+// Dequeue method that removes the first item from the items of the queue
+func (q *fileQueue[T]) Dequeue() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.items) == 0 {
+		return
+	}
+	q.items = q.items[1:]
+	q.dequeued++
+
+	logger := container.LoggerFrom(q.dic.Get)
+	if q.dequeued >= compactThreshold && q.dequeued >= len(q.items) {
+		if err := q.compact(); err != nil {
+			logger.Warnf("Failed to compact the queue file '%s', err: %v", q.filePath, err)
+		}
+		return
+	}
+	if err := q.writeOffset(q.dequeued); err != nil {
+		logger.Warnf("Failed to record the offset of the queue file '%s', err: %v", q.filePath, err)
+	}
+}
+
+// Peek method that looks at the next item without removing it from the queue
+func (q *fileQueue[T]) Peek() T {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.items) > 0 {
+		return q.items[0]
+	}
+
+	return *new(T)
+}
+
+// Size returns a number indicating how many items are in the queue
+func (q *fileQueue[T]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return len(q.items)
+}
+
+// load reads the items which haven't been dequeued from the queue file. It returns true if the queue file has to be
+// rewritten because it contains lines which can't be decoded, e.g. the partially written last item if the service
+// stopped while writing it, so that the offset keeps counting the lines of the dequeued items and the next item isn't
+// appended to an incomplete line.
+func (q *fileQueue[T]) load() (bool, error) {
+	offset, err := q.readOffset()
+	if err != nil {
+		return false, err
+	}
+
+	file, err := os.Open(q.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	logger := container.LoggerFrom(q.dic.Get)
+	rewrite := false
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxItemSize)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			// The last line isn't terminated by a newline, so the item is partially written and dropped
+			logger.Warnf("Drop the partially written last item of the queue file '%s'", q.filePath)
+			rewrite = true
+			return len(data), nil, nil
+		}
+		return 0, nil, nil
+	})
+	line := 0
+	for scanner.Scan() {
+		line++
+		if line <= offset {
+			q.dequeued++
+			continue
+		}
+		var item T
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			logger.Warnf("Failed to decode the item at line %d of the queue file '%s', err: %v", line, q.filePath, err)
+			rewrite = true
+			continue
+		}
+		q.items = append(q.items, item)
+	}
+	return rewrite, scanner.Err()
+}
+
+// compact rewrites the queue file with the items which haven't been dequeued. The offset is reset before the queue
+// file is replaced, so that the items are retried again rather than lost if the service stops in between.
+func (q *fileQueue[T]) compact() error {
+	tmpPath := q.filePath + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(tmp)
+	for _, item := range q.items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		_, _ = writer.Write(append(data, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := q.writeOffset(0); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, q.filePath); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(q.filePath, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	_ = q.file.Close()
+	q.file = file
+	q.dequeued = 0
+	return nil
+}
+
+// close closes the queue file once the retry loop exits
+func (q *fileQueue[T]) close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.file != nil {
+		_ = q.file.Close()
+		q.file = nil
+	}
+}
+
+func (q *fileQueue[T]) readOffset() (int, error) {
+	data, err := os.ReadFile(q.filePath + offsetFileSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func (q *fileQueue[T]) writeOffset(offset int) error {
+	offsetPath := q.filePath + offsetFileSuffix
+	tmpPath := filepath.Join(filepath.Dir(offsetPath), "."+filepath.Base(offsetPath)+".tmp")
+	if err := os.WriteFile(tmpPath, []byte(strconv.Itoa(offset)), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, offsetPath)
+}
@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package re_exec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/bootstrap/container"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+type testItem struct {
+	Name  string
+	Value int
+}
+
+func newTestDic() *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		container.LoggerInterfaceName: func(get di.Get) any {
+			return log.NewNopeLogger()
+		},
+	})
+}
+
+func TestFileQueuePersistence(t *testing.T) {
+	dic := newTestDic()
+	filePath := filepath.Join(t.TempDir(), "queue")
+	failing := func(context.Context, *di.Container, testItem) bool {
+		return false
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queue, err := NewFileQueue[testItem](dic, ctx, filePath, 2, "1h", failing)
+	require.NoError(t, err)
+	require.NoError(t, queue.Enqueue(testItem{"a", 1}))
+	require.NoError(t, queue.Enqueue(testItem{"b", 2}))
+	err = queue.Enqueue(testItem{"c", 3})
+	require.Error(t, err)
+	assert.Equal(t, errors.KindLimitExceeded, errors.Kind(err))
+	queue.Dequeue()
+	assert.Equal(t, 1, queue.Size())
+	cancel()
+
+	// The items which haven't been dequeued are loaded after restart
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	queue, err = NewFileQueue[testItem](dic, ctx, filePath, 2, "1h", failing)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queue.Size())
+	assert.Equal(t, testItem{"b", 2}, queue.Peek())
+}
+
+func TestFileQueueLoadPartialItem(t *testing.T) {
+	dic := newTestDic()
+	filePath := filepath.Join(t.TempDir(), "queue")
+	failing := func(context.Context, *di.Container, testItem) bool {
+		return false
+	}
+
+	// The first item has been dequeued, the third line can't be decoded and the last item is partially written
+	contents := `{"Name":"x","Value":0}` + "\n" +
+		`{"Name":"a","Value":1}` + "\n" +
+		"invalid\n" +
+		`{"Name":"b","Value":2}` + "\n" +
+		`{"Name":"c","Val`
+	require.NoError(t, os.WriteFile(filePath, []byte(contents), 0600))
+	require.NoError(t, os.WriteFile(filePath+offsetFileSuffix, []byte("1"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queue, err := NewFileQueue[testItem](dic, ctx, filePath, 0, "1h", failing)
+	require.NoError(t, err)
+	assert.Equal(t, 2, queue.Size())
+	assert.Equal(t, testItem{"a", 1}, queue.Peek())
+	require.NoError(t, queue.Enqueue(testItem{"d", 4}))
+	queue.Dequeue()
+	cancel()
+
+	// Neither the appended item nor the item after the dequeued one is lost after restart
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	queue, err = NewFileQueue[testItem](dic, ctx, filePath, 0, "1h", failing)
+	require.NoError(t, err)
+	require.Equal(t, 2, queue.Size())
+	assert.Equal(t, testItem{"b", 2}, queue.Peek())
+	queue.Dequeue()
+	assert.Equal(t, testItem{"d", 4}, queue.Peek())
+}
+
+func TestFileQueueRetry(t *testing.T) {
+	dic := newTestDic()
+	filePath := filepath.Join(t.TempDir(), "queue")
+
+	var available atomic.Bool
+	var retried atomic.Int32
+	retry := func(_ context.Context, _ *di.Container, item testItem) bool {
+		if !available.Load() {
+			return false
+		}
+		retried.Add(1)
+		return true
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queue, err := NewFileQueue[testItem](dic, ctx, filePath, 0, "10ms", retry)
+	require.NoError(t, err)
+	for i := 0; i < compactThreshold+10; i++ {
+		require.NoError(t, queue.Enqueue(testItem{"item", i}))
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, compactThreshold+10, queue.Size())
+
+	available.Store(true)
+	require.Eventually(t, func() bool {
+		return queue.Size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(compactThreshold+10), retried.Load())
+
+	// The queue file is compacted once most of the items are dequeued
+	info, statErr := os.Stat(filePath)
+	require.NoError(t, statErr)
+	assert.Less(t, info.Size(), int64(compactThreshold*10))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/di"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
 )
 
 const (
@@ -20,6 +21,9 @@ const (
 	DefaultRetryInterval = "10m"
 )
 
+// dropLogSampling limits the traces of dropping items when the queue limit is exceeded
+var dropLogSampling = log.SamplingConfig{Interval: 10 * time.Second, First: 10}
+
 type ReExecFunc[T any] func(context.Context, *di.Container, T) bool
 
 type memoryQueue[T any] struct {
@@ -29,6 +33,8 @@ type memoryQueue[T any] struct {
 	retryInterval time.Duration
 	items         []T
 	lock          sync.Mutex
+	// dropLogger is a sampled logger for the repetitive traces of dropping items
+	dropLogger log.Logger
 }
 
 // NewMemoryQueue is a factory method that returns an initialized ReExecQueue.
@@ -39,15 +45,7 @@ func NewMemoryQueue[T any](dic *di.Container, ctx context.Context, queueLimit in
 		queueLimit = DefaultMaxQueueLimit
 	}
 
-	if retryInterval == "" {
-		retryInterval = DefaultRetryInterval
-	}
-
-	interval, err := time.ParseDuration(retryInterval)
-	if err != nil {
-		logger.Warnf("Failed to parse RetryInterval '%s', set to default '%s', err: %v", retryInterval, DefaultRetryInterval, err)
-		interval, _ = time.ParseDuration(DefaultRetryInterval)
-	}
+	interval := parseRetryInterval(logger, retryInterval)
 
 	q := &memoryQueue[T]{
 		dic:           dic,
@@ -55,22 +53,22 @@ func NewMemoryQueue[T any](dic *di.Container, ctx context.Context, queueLimit in
 		queueLimit:    queueLimit,
 		retryInterval: interval,
 		lock:          sync.Mutex{},
+		dropLogger:    log.Sampled(logger, dropLogSampling),
 	}
 
 	logger.Debugf("Start MemoryQueue with QueueLimit '%d' and RetryInterval '%s'", queueLimit, interval)
-	go q.reExecLoop(fun)
+	go reExecLoop(q.ctx, q.dic, q, q.retryInterval, fun)
 
 	return q
 }
 
 // Enqueue method that adds a new item to the queue
 func (q *memoryQueue[T]) Enqueue(item T) errors.Error {
-	logger := container.LoggerFrom(q.dic.Get)
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
 	if len(q.items) >= q.queueLimit {
-		logger.Tracef("Exceeded queue limit, drop the item: %v", item)
+		q.dropLogger.Tracef("Exceeded queue limit, drop the item: %v", item)
 		return errors.NewBaseError(errors.KindLimitExceeded, "Exceeded queue limit, drop the item", nil)
 	}
 	q.items = append(q.items, item)
@@ -108,19 +106,33 @@ func (q *memoryQueue[T]) Size() int {
 	return len(q.items)
 }
 
-// reExecLoop method that triggers the retry function at intervals
-func (q *memoryQueue[T]) reExecLoop(fun ReExecFunc[T]) {
-	logger := container.LoggerFrom(q.dic.Get)
+// parseRetryInterval parses the retry interval, which falls back to DefaultRetryInterval if it is empty or invalid
+func parseRetryInterval(logger log.Logger, retryInterval string) time.Duration {
+	if retryInterval == "" {
+		retryInterval = DefaultRetryInterval
+	}
+
+	interval, err := time.ParseDuration(retryInterval)
+	if err != nil {
+		logger.Warnf("Failed to parse RetryInterval '%s', set to default '%s', err: %v", retryInterval, DefaultRetryInterval, err)
+		interval, _ = time.ParseDuration(DefaultRetryInterval)
+	}
+	return interval
+}
+
+// reExecLoop triggers the retry function on the items of the queue at intervals until the context is done
+func reExecLoop[T any](ctx context.Context, dic *di.Container, q common.Queue[T], retryInterval time.Duration, fun ReExecFunc[T]) {
+	logger := container.LoggerFrom(dic.Get)
 
 	for {
 		select {
-		case <-q.ctx.Done():
+		case <-ctx.Done():
 			logger.Info("Exiting retry loop")
 			return
-		case <-time.After(q.retryInterval):
+		case <-time.After(retryInterval):
 			for q.Size() != 0 {
 				item := q.Peek()
-				ok := fun(q.ctx, q.dic, item)
+				ok := fun(ctx, dic, item)
 				if !ok {
 					logger.Tracef("Retry failed, '%d' items in the queue", q.Size())
 					break
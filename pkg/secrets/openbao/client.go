@@ -60,7 +60,7 @@ func NewClient(config types.SecretConfig, requester secrets.Caller, forSecrets b
 	secretStoreClient := Client{
 		Config:                          config,
 		HttpCaller:                      requester,
-		lc:                              lc,
+		lc:                              log.Component(lc, logComponent),
 		mapMutex:                        sync.Mutex{},
 		secretStoreTokenToCancelFuncMap: make(secretStoreTokenToCancelFuncMap),
 	}
@@ -5,6 +5,9 @@
 
 package openbao
 
+// logComponent is the name of the component logger used by the OpenBao client
+const logComponent = "secrets"
+
 const (
 	// NamespaceHeader specifies the header name to use when including Namespace information in a request.
 	NamespaceHeader = "X-Vault-Namespace"
@@ -0,0 +1,122 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+
+	authJWT "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/auth/jwt"
+)
+
+// errEmptyTopic is returned if the authorizer narrows the topic to an empty one
+var errEmptyTopic = errors.New("authorizer returned an empty topic")
+
+// authorize returns the topic to subscribe by the client, which is the resolved topic unless it is narrowed by the
+// authorizer, or an error if the subscription is rejected.
+func (config *HandlerConfig) authorize(c echo.Context, topic string) (string, error) {
+	if config.Authorizer == nil {
+		return topic, nil
+	}
+	claims, ok := config.verifiedClaims(c)
+	if !ok {
+		claims = jwt.MapClaims{}
+	}
+	authorized, authErr := config.Authorizer(c, topic, claims)
+	if authErr != nil {
+		return "", authErr
+	}
+	if authorized == "" {
+		return "", errEmptyTopic
+	}
+	return authorized, nil
+}
+
+// acquireConnection counts the connection of the client against MaxConnectionsPerUser, and returns the function
+// releasing it, or false if the user of the client already reaches the cap.
+func (config *HandlerConfig) acquireConnection(c echo.Context, m *Manager) (release func(), ok bool) {
+	if config.MaxConnectionsPerUser <= 0 {
+		return func() {}, true
+	}
+	user := config.connectionUser(c)
+	if !m.acquireConnection(user, config.MaxConnectionsPerUser) {
+		return nil, false
+	}
+	return func() {
+		m.releaseConnection(user)
+	}, true
+}
+
+// verifiedClaims returns the verified claims of the JWT of the request, which are set by the authentication middleware
+// unless ClaimsFunc is set, or false if the request isn't authenticated.
+func (config *HandlerConfig) verifiedClaims(c echo.Context) (jwt.MapClaims, bool) {
+	if config.ClaimsFunc != nil {
+		return config.ClaimsFunc(c)
+	}
+	return authJWT.VerifiedClaimsFromContext(c)
+}
+
+// connectionUser identifies the user of the client by the verified claims of the request, or by the client IP address
+// if there is no verified identity, so that the clients can't evade the cap with forged JWTs.
+func (config *HandlerConfig) connectionUser(c echo.Context) string {
+	if claims, ok := config.verifiedClaims(c); ok {
+		if username := authJWT.UsernameFromClaims(claims); username != "" {
+			return "user:" + username
+		}
+	}
+	return "ip:" + c.RealIP()
+}
+
+// acquireConnection counts a connection of the user, and returns false if the user already has max connections.
+func (m *Manager) acquireConnection(user string, max int) bool {
+	m.connMu.Lock()
+	defer m.connMu.Unlock()
+
+	if m.connections[user] >= max {
+		return false
+	}
+	m.connections[user]++
+	return true
+}
+
+// releaseConnection uncounts a connection of the user.
+func (m *Manager) releaseConnection(user string) {
+	m.connMu.Lock()
+	defer m.connMu.Unlock()
+
+	m.connections[user]--
+	if m.connections[user] <= 0 {
+		delete(m.connections, user)
+	}
+}
+
+// admit authorizes the subscription of the client to the topic and counts its connection, and returns the topic to
+// subscribe and the function releasing the connection, or the HTTP error rejecting the client.
+func (config *HandlerConfig) admit(c echo.Context, m *Manager, topic string) (string, func(), error) {
+	authorized, err := config.authorize(c, topic)
+	if err != nil {
+		m.lc.Debugf("sse: Subscription to topic '%s' is rejected: %v", topic, err)
+		return "", nil, echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+	release, err := config.admitConnection(c, m)
+	if err != nil {
+		return "", nil, err
+	}
+	return authorized, release, nil
+}
+
+// admitConnection counts the connection of the client, and returns the function releasing it, or the HTTP error
+// rejecting the client if its user already reaches MaxConnectionsPerUser.
+func (config *HandlerConfig) admitConnection(c echo.Context, m *Manager) (func(), error) {
+	release, ok := config.acquireConnection(c, m)
+	if !ok {
+		m.lc.Debugf("sse: Connection is rejected: %s reaches the cap of %d connections", config.connectionUser(c), config.MaxConnectionsPerUser)
+		return nil, echo.NewHTTPError(http.StatusTooManyRequests, "too many concurrent connections")
+	}
+	return release, nil
+}
@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	authJWT "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/auth/jwt"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+// testSigningKey is the key of the JWT verified by verifyTestClaims
+var testSigningKey = []byte("secret")
+
+// signedBearerHeader returns the Authorization header carrying a JWT of the user signed with the key
+func signedBearerHeader(t *testing.T, username string, key []byte) http.Header {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{authJWT.ClaimUsername: username}).SignedString(key)
+	require.NoError(t, err)
+	return http.Header{"Authorization": []string{"Bearer " + token}}
+}
+
+// bearerHeader returns the Authorization header carrying a valid JWT of the user
+func bearerHeader(t *testing.T, username string) http.Header {
+	return signedBearerHeader(t, username, testSigningKey)
+}
+
+// forgedBearerHeader returns the Authorization header carrying a JWT of the user which fails the verification
+func forgedBearerHeader(t *testing.T, username string) http.Header {
+	return signedBearerHeader(t, username, []byte("forged"))
+}
+
+// verifyTestClaims is a ClaimsFunc verifying the JWT of the request with testSigningKey
+func verifyTestClaims(c echo.Context) (jwt.MapClaims, bool) {
+	claims := jwt.MapClaims{}
+	token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+	if _, err := jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (any, error) {
+		return testSigningKey, nil
+	}); err != nil {
+		return nil, false
+	}
+	return claims, true
+}
+
+// statusOf returns the status code of the request rejected by the handler
+func statusOf(t *testing.T, url string, header http.Header) int {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// userTopicAuthorizer narrows the topic to the user, and rejects the clients without a user
+func userTopicAuthorizer(_ echo.Context, topic string, claims jwt.MapClaims) (string, error) {
+	username := authJWT.UsernameFromClaims(claims)
+	if username == "" || username == "mallory" {
+		return "", errors.New("access denied")
+	}
+	return topic + "/" + username, nil
+}
+
+func TestHandlerAuthorizer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithAuthorizer(userTopicAuthorizer), WithClaimsFunc(verifyTestClaims))
+
+	stream := openStream(t, server.URL+"/sse", bearerHeader(t, "alice"))
+	b := waitForSubscribers(t, m, testTopic+"/alice", 1)
+	b.Publish("alice only")
+	assert.Equal(t, map[string]string{"id": "1", "data": `"alice only"`}, stream.next(t))
+
+	assert.Equal(t, http.StatusForbidden, statusOf(t, server.URL+"/sse", bearerHeader(t, "mallory")))
+	assert.Equal(t, http.StatusForbidden, statusOf(t, server.URL+"/sse", nil))
+	// The claims of a JWT failing the verification aren't passed to the authorizer
+	assert.Equal(t, http.StatusForbidden, statusOf(t, server.URL+"/sse", forgedBearerHeader(t, "alice")))
+	_, ok := m.GetBroadcaster(testTopic)
+	assert.False(t, ok, "no broadcaster should be created for the rejected clients")
+}
+
+func TestHandlerMaxConnectionsPerUser(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithMaxConnectionsPerUser(1), WithClaimsFunc(verifyTestClaims))
+
+	alice := openStream(t, server.URL+"/sse", bearerHeader(t, "alice"))
+	waitForSubscribers(t, m, testTopic, 1)
+	assert.Equal(t, http.StatusTooManyRequests, statusOf(t, server.URL+"/sse", bearerHeader(t, "alice")))
+
+	// The other users are counted separately
+	openStream(t, server.URL+"/sse", bearerHeader(t, "bob"))
+	waitForSubscribers(t, m, testTopic, 2)
+
+	// The connection is released once the client disconnects
+	require.NoError(t, alice.resp.Body.Close())
+	waitForSubscribers(t, m, testTopic, 1)
+	require.Eventually(t, func() bool {
+		m.connMu.Lock()
+		defer m.connMu.Unlock()
+		return m.connections["user:alice"] == 0
+	}, 2*time.Second, 10*time.Millisecond)
+	openStream(t, server.URL+"/sse", bearerHeader(t, "alice"))
+	waitForSubscribers(t, m, testTopic, 2)
+}
+
+func TestHandlerMaxConnectionsPerUserForged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	e := echo.New()
+	// The claims verified by the authentication middleware identify the users by default
+	e.GET("/sse", Handler(m, WithCustomTopic(testTopic), WithMaxConnectionsPerUser(1)), func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if claims, ok := verifyTestClaims(c); ok {
+				authJWT.SetVerifiedClaims(c, claims)
+			}
+			return next(c)
+		}
+	})
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	openStream(t, server.URL+"/sse", bearerHeader(t, "alice"))
+	waitForSubscribers(t, m, testTopic, 1)
+
+	// The clients with the forged JWTs are identified by their IP address rather than the forged usernames
+	openStream(t, server.URL+"/sse", forgedBearerHeader(t, "bob"))
+	waitForSubscribers(t, m, testTopic, 2)
+	assert.Equal(t, http.StatusTooManyRequests, statusOf(t, server.URL+"/sse", forgedBearerHeader(t, "carol")))
+	assert.Equal(t, http.StatusTooManyRequests, statusOf(t, server.URL+"/sse", bearerHeader(t, "alice")))
+}
+
+func TestLongPollingHandlerAuthorizer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	e := echo.New()
+	e.GET("/poll", LongPollingHandler(m, WithAuthorizer(userTopicAuthorizer)))
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	assert.Equal(t, http.StatusForbidden, statusOf(t, server.URL+"/poll", nil))
+}
+
+func TestWebSocketHandlerAuthorizer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	ws := dialWebSocket(t, m, WithAuthorizer(func(_ echo.Context, topic string, _ jwt.MapClaims) (string, error) {
+		if strings.HasPrefix(topic, "admin/") {
+			return "", errors.New("access denied")
+		}
+		return topic + "?tenant=t1", nil
+	}))
+
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "admin/users"}))
+	msg := receiveMessage(t, ws)
+	assert.Equal(t, "admin/users", msg.Topic)
+	assert.Equal(t, EventTypeError, msg.Event)
+	var resp rest.BaseResponse
+	require.NoError(t, json.Unmarshal(msg.Data, &resp))
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// The events of the narrowed topic are sent under the requested topic
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "devices"}))
+	b := waitForSubscribers(t, m, "devices?tenant=t1", 1)
+	b.Publish("d1")
+	assert.Equal(t, WebSocketMessage{Topic: "devices", ID: 1, Data: []byte(`"d1"`)}, receiveMessage(t, ws))
+}
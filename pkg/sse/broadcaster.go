@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2025 IOTech Ltd
+// Copyright (C) 2025-2026 IOTech Ltd
 //
 
 package sse
@@ -9,40 +9,124 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
 )
 
-// SubscriberCh is a channel type used for broadcasting messages.
+// dropLogSampling limits the warnings of dropping data for slow subscribers, which can be logged thousands of times
+// per second under load
+var dropLogSampling = log.SamplingConfig{Interval: 10 * time.Second, First: 1}
+
+// DefaultEventHistorySize is the default number of the recent events kept by a Broadcaster for replaying
+const DefaultEventHistorySize = 16
+
+// DefaultSubscriberBufferSize is the default size of the channel buffering the messages of a subscriber
+const DefaultSubscriberBufferSize = 64
+
+// OverflowPolicy defines how a subscriber is handled if its channel is full, i.e. the subscriber is too slow to
+// receive the published messages.
+type OverflowPolicy string
+
+const (
+	// OverflowDropNewest drops the new messages until the subscriber catches up. This is the default policy.
+	OverflowDropNewest OverflowPolicy = "drop-newest"
+	// OverflowDropOldest drops the oldest pending message to make room for the new one.
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowLatestOnly coalesces the messages, so that only the latest message is pending for the subscriber.
+	OverflowLatestOnly OverflowPolicy = "latest-only"
+	// OverflowDisconnect closes the channel of the subscriber, so that the client reconnects and replays the
+	// missed events.
+	OverflowDisconnect OverflowPolicy = "disconnect"
+)
+
+// EventTypeError is the event type of the error responses published when polling fails
+const EventTypeError = "error"
+
+// SubscriberCh is a channel type used for broadcasting messages. The messages are the published data, or Event if the
+// subscriber subscribes WithEvents.
 type SubscriberCh chan any
 
+// Event is a message broadcast to the subscribers.
+type Event struct {
+	// ID is the monotonically increasing ID of the event within the Broadcaster, starting from 1.
+	ID uint64
+	// Type is the name of the event type, empty for the default message type.
+	Type string
+	// Data is the payload of the event.
+	Data any
+}
+
+// TransformFunc projects the published data for a subscriber. It returns false if the data should not be delivered
+// to the subscriber at all. The transform is called for each subscriber on every publish, so it must be fast.
+type TransformFunc func(eventType string, data any) (any, bool)
+
 type Subscriber struct {
 	ch    SubscriberCh
 	isNew bool
+	// transform is the optional projection of the published data for the subscriber
+	transform TransformFunc
+	// lastHash is the hash of the last projection delivered to the subscriber, for deduplicating the projections
+	lastHash       string
+	overflowPolicy OverflowPolicy
+	// events indicates whether the messages are delivered as Event rather than the published data
+	events bool
+	// dropped is the number of the messages dropped for the subscriber
+	dropped uint64
+	closed  bool
+}
+
+// eventState is the state of the events of a topic, which is retained by the Manager after its broadcaster is
+// removed, so that the event IDs and history carry over to the next broadcaster of the topic.
+type eventState struct {
+	lastHash  string
+	lastEvent *Event
+	history   []Event
 }
 
 // Broadcaster manages a set of subscribers and broadcasts messages to them.
 type Broadcaster struct {
 	lc log.Logger
+	// dropLc is a sampled logger for the repetitive warnings of dropping data
+	dropLc log.Logger
 	// subscribers hold the active subscribers.
 	subscribers map[SubscriberCh]*Subscriber
 	mu          sync.RWMutex
-	lastHash    common.AtomicString
+	// lastHash is the hash of the last changed data, which is guarded by mu together with lastEvent and history
+	lastHash string
+	// lastEvent is the latest event, which is sent to the new subscribers
+	lastEvent *Event
+	// history is the ring buffer of the recent events for replaying to the reconnecting subscribers
+	history     []Event
+	historySize int
+	// published is the number of the published messages including the unchanged ones, and lastPublished is the
+	// time of the last one
+	published     uint64
+	lastPublished time.Time
+	// dropped is the number of the messages dropped for all the subscribers, including the removed ones
+	dropped uint64
+	polling atomic.Bool
 
 	pollingService PollingService
 	onEmptyCb      func()
 	once           sync.Once
+	// idleTimeout is how long the broadcaster is kept after the last subscriber leaves, and emptyGeneration
+	// identifies each time the broadcaster becomes empty
+	idleTimeout     time.Duration
+	emptyGeneration uint64
+	// relay relays the changed data to the other instances through the Bus of the Manager, nil if there is no Bus
+	relay func(eventType string, data any)
 }
 
 // NewBroadcaster creates a new instance of Broadcaster.
 func NewBroadcaster(lc log.Logger) *Broadcaster {
-	b := &Broadcaster{
+	return &Broadcaster{
 		lc:          lc,
+		dropLc:      log.Sampled(lc, dropLogSampling),
 		subscribers: make(map[SubscriberCh]*Subscriber),
+		historySize: DefaultEventHistorySize,
 	}
-	b.lastHash.Set("")
-	return b
 }
 
 // SetPollingService sets the polling service for the broadcaster if auto-polling is required.
@@ -50,18 +134,81 @@ func (b *Broadcaster) SetPollingService(service PollingService) {
 	b.pollingService = service
 }
 
+// SetEventHistorySize sets the number of the recent events kept for replaying to the reconnecting subscribers.
+// A size of zero or less disables the replay.
+func (b *Broadcaster) SetEventHistorySize(size int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.historySize = max(size, 0)
+	if len(b.history) > b.historySize {
+		b.history = append([]Event(nil), b.history[len(b.history)-b.historySize:]...)
+	}
+}
+
+// SetIdleTimeout sets how long the broadcaster keeps polling after the last subscriber leaves before it is stopped and
+// the onEmpty callback is called, so that the clients reconnecting within the timeout, e.g. the long-polling clients,
+// share the same polling and event history. Default is zero, which stops the broadcaster immediately.
+func (b *Broadcaster) SetIdleTimeout(timeout time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.idleTimeout = timeout
+}
+
+// extendIdleTimeout raises the idle timeout of the broadcaster to the timeout if it is shorter, so that a topic shared
+// by the handlers is kept for the longest idle timeout of its clients.
+func (b *Broadcaster) extendIdleTimeout(timeout time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.idleTimeout = max(b.idleTimeout, timeout)
+}
+
 // SetOnEmptyCallback sets a callback function that will be called when there are no subscribers left.
 func (b *Broadcaster) SetOnEmptyCallback(f func()) {
 	b.onEmptyCb = f
 }
 
-// Subscribe adds a new subscriber and returns a channel to receive messages.
-func (b *Broadcaster) Subscribe() SubscriberCh {
-	ch := make(SubscriberCh, 64)
+// Subscribe adds a new subscriber and returns a channel to receive messages, which are the published data unless
+// WithEvents is set. If the subscriber is reconnecting with the ID of the last event it received, the buffered events
+// after that ID are replayed to the channel first. An ID newer than the latest event is from an earlier sequence of
+// the topic, e.g. one whose retained events have expired, and the subscriber is handled as a new one.
+func (b *Broadcaster) Subscribe(opts ...SubscribeOption) SubscriberCh {
+	config := &SubscribeConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	bufferSize := config.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriberBufferSize
+	}
 	b.mu.Lock()
-	b.subscribers[ch] = &Subscriber{
-		ch:    ch,
-		isNew: true,
+	if config.LastEventID > 0 {
+		// The channel holds all the replayed events, so that a reconnecting subscriber isn't dropped or disconnected
+		// by the overflow policy again before it receives them
+		bufferSize = max(bufferSize, len(b.history)+1)
+	}
+	if config.OverflowPolicy == OverflowLatestOnly {
+		bufferSize = 1
+	}
+	ch := make(SubscriberCh, bufferSize)
+	s := &Subscriber{
+		ch:             ch,
+		isNew:          true,
+		transform:      config.Transform,
+		overflowPolicy: config.OverflowPolicy,
+		events:         config.Events,
+	}
+	if config.LastEventID > 0 {
+		b.replay(s, config.LastEventID)
+	}
+	if config.LatestEvent && s.isNew && b.lastEvent != nil {
+		b.deliver(s, *b.lastEvent)
+	}
+	if !s.closed {
+		b.subscribers[ch] = s
 	}
 	b.mu.Unlock()
 
@@ -70,20 +217,56 @@ func (b *Broadcaster) Subscribe() SubscriberCh {
 }
 
 // Unsubscribe should only be deferred after the subscription to ensure the channel will be closed properly.
+// It does nothing if the subscriber has been disconnected by the OverflowDisconnect policy.
 func (b *Broadcaster) Unsubscribe(ch SubscriberCh) {
 	b.mu.Lock()
-	delete(b.subscribers, ch)
-	close(ch)
+	defer b.mu.Unlock()
+
+	if s, ok := b.subscribers[ch]; ok {
+		b.removeSubscriber(s)
+	}
+}
+
+// Dropped returns the number of the messages dropped for the subscriber of the channel.
+func (b *Broadcaster) Dropped(ch SubscriberCh) uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if s, ok := b.subscribers[ch]; ok {
+		return s.dropped
+	}
+	return 0
+}
+
+// removeSubscriber removes the subscriber and closes its channel, which must be called with the lock held.
+func (b *Broadcaster) removeSubscriber(s *Subscriber) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(b.subscribers, s.ch)
+	close(s.ch)
 
 	b.lc.Debugf("sse: Subscriber removed, total=%d", len(b.subscribers))
 
 	if len(b.subscribers) == 0 {
-		go b.handleNoSubscribers()
+		b.emptyGeneration++
+		go b.handleNoSubscribers(b.emptyGeneration, b.idleTimeout)
 	}
-	b.mu.Unlock()
 }
 
-func (b *Broadcaster) handleNoSubscribers() {
+func (b *Broadcaster) handleNoSubscribers(generation uint64, idleTimeout time.Duration) {
+	if idleTimeout > 0 {
+		time.Sleep(idleTimeout)
+		b.mu.RLock()
+		// Keep the broadcaster if a subscriber joins within the timeout
+		resumed := len(b.subscribers) > 0 || generation != b.emptyGeneration
+		b.mu.RUnlock()
+		if resumed {
+			return
+		}
+	}
+
 	// Stop the polling service if it is set and there are no subscribers left
 	if b.pollingService != nil {
 		if err := b.StopPolling(); err != nil {
@@ -96,25 +279,186 @@ func (b *Broadcaster) handleNoSubscribers() {
 	}
 }
 
+// replay sends the buffered events after lastEventID to the subscriber. The subscriber is no longer considered new
+// if it is up-to-date after the replay, so that the latest event isn't sent to it again.
+func (b *Broadcaster) replay(s *Subscriber, lastEventID uint64) {
+	if b.lastEvent == nil || lastEventID > b.lastEvent.ID {
+		b.lc.Debugf("sse: Ignoring event ID %d from an earlier sequence", lastEventID)
+		return
+	}
+	replayed := 0
+	for _, event := range b.history {
+		if event.ID <= lastEventID {
+			continue
+		}
+		if b.deliver(s, event) {
+			replayed++
+		}
+	}
+	if replayed > 0 || lastEventID == b.lastEvent.ID {
+		s.isNew = false
+	}
+	b.lc.Debugf("sse: Replayed %d events after event ID %d", replayed, lastEventID)
+}
+
 // Publish sends data to all subscribers.
 func (b *Broadcaster) Publish(data any) {
-	shouldSend := b.shouldSendUpdate(data)
+	b.PublishEvent("", data)
+}
 
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-	for ch, s := range b.subscribers {
+// PublishEvent sends data under the named event type to all subscribers. The changed data is also relayed to the
+// other instances if the Manager is configured with a Bus.
+func (b *Broadcaster) PublishEvent(eventType string, data any) {
+	if b.publishEvent(eventType, data) && b.relay != nil {
+		b.relay(eventType, data)
+	}
+}
+
+// publishEvent sends data under the named event type to the local subscribers, and returns whether the data has
+// changed.
+func (b *Broadcaster) publishEvent(eventType string, data any) bool {
+	// The event type is part of the hash, so that the same data under another event type is sent as an update. The
+	// data is encoded before locking, but the hash is compared under the lock along with the updates of the events.
+	hash, err := hashOf([]any{eventType, data})
+	if err != nil {
+		b.lc.Errorf("sse: Failed to marshal data for hash comparison: %v", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	shouldSend := err == nil && b.updateHash(hash)
+	b.published++
+	b.lastPublished = time.Now()
+	if shouldSend {
+		var id uint64 = 1
+		if b.lastEvent != nil {
+			id = b.lastEvent.ID + 1
+		}
+		b.lastEvent = &Event{ID: id, Type: eventType, Data: data}
+		b.appendHistory(*b.lastEvent)
+	}
+	if b.lastEvent == nil {
+		return false
+	}
+
+	for _, s := range b.subscribers {
 		// Only send data to subscribers that are new or if the data has changed
 		if s.isNew || shouldSend {
-			select {
-			case ch <- data:
-				if s.isNew {
-					s.isNew = false // Mark the subscriber as no longer new after the first message
-				}
-			default: // if the channel is full, dropping to avoid blocking
-				b.lc.Warn("sse: Subscriber channel is full, dropping data")
-			}
+			b.deliver(s, *b.lastEvent)
 		}
 	}
+	return shouldSend
+}
+
+// deliver sends the event to the subscriber without blocking, with the data projected by the transform of the
+// subscriber if any. The event is skipped if the transform filters it out or the projection hasn't changed since the
+// last event delivered to the subscriber, so that the subscribers sharing a broadcaster are deduplicated separately.
+func (b *Broadcaster) deliver(s *Subscriber, event Event) bool {
+	var hash string
+	if s.transform != nil {
+		data, ok := s.transform(event.Type, event.Data)
+		if !ok {
+			return false
+		}
+		var err error
+		hash, err = hashOf([]any{event.Type, data})
+		if err != nil {
+			b.lc.Errorf("sse: Failed to marshal data for hash comparison: %v", err)
+			return false
+		}
+		if hash == s.lastHash {
+			return false
+		}
+		event.Data = data
+	}
+
+	if !b.send(s, event) {
+		return false
+	}
+	s.isNew = false // Mark the subscriber as no longer new after the first message
+	s.lastHash = hash
+	return true
+}
+
+func (b *Broadcaster) countDrop(s *Subscriber) {
+	s.dropped++
+	b.dropped++
+}
+
+// send sends the event to the channel of the subscriber without blocking, and applies the overflow policy of the
+// subscriber if the channel is full.
+func (b *Broadcaster) send(s *Subscriber, event Event) bool {
+	if s.closed {
+		return false
+	}
+	var msg any = event
+	if !s.events {
+		msg = event.Data
+	}
+	select {
+	case s.ch <- msg:
+		return true
+	default:
+	}
+
+	switch s.overflowPolicy {
+	case OverflowDropOldest, OverflowLatestOnly:
+		// Discard the oldest pending message to make room for the new one
+		select {
+		case <-s.ch:
+			b.countDrop(s)
+		default:
+		}
+		select {
+		case s.ch <- msg:
+			return true
+		default:
+		}
+	case OverflowDisconnect:
+		b.dropLc.Warn("sse: Subscriber channel is full, disconnecting the slow subscriber")
+		b.countDrop(s)
+		b.removeSubscriber(s)
+		return false
+	}
+
+	// if the channel is full, dropping to avoid blocking
+	b.dropLc.Warn("sse: Subscriber channel is full, dropping data")
+	b.countDrop(s)
+	return false
+}
+
+func (b *Broadcaster) appendHistory(event Event) {
+	if b.historySize <= 0 {
+		return
+	}
+	if len(b.history) < b.historySize {
+		b.history = append(b.history, event)
+		return
+	}
+	copy(b.history, b.history[1:])
+	b.history[len(b.history)-1] = event
+}
+
+// snapshot returns the state of the events of the broadcaster for the Manager to retain.
+func (b *Broadcaster) snapshot() eventState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return eventState{lastHash: b.lastHash, lastEvent: b.lastEvent, history: append([]Event(nil), b.history...)}
+}
+
+// restore continues the events of the broadcaster from the state retained from the previous broadcaster of the topic,
+// which must be called before the broadcaster is shared.
+func (b *Broadcaster) restore(state eventState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastHash = state.lastHash
+	b.lastEvent = state.lastEvent
+	b.history = append([]Event(nil), state.history...)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
 }
 
 // StartPolling starts the polling service if it is set.
@@ -125,6 +469,7 @@ func (b *Broadcaster) StartPolling() {
 	// Use sync.Once to ensure the polling service is started only once for the same broadcaster instance.
 	b.once.Do(func() {
 		b.pollingService.Start(b)
+		b.polling.Store(true)
 	})
 }
 
@@ -134,18 +479,27 @@ func (b *Broadcaster) StopPolling() error {
 		b.lc.Debug("sse: StopPolling: no polling service defined")
 		return nil
 	}
+	b.polling.Store(false)
 	return b.pollingService.Stop()
 }
 
-func (b *Broadcaster) shouldSendUpdate(data any) bool {
+// updateHash records the hash of the latest data, and returns whether it differs from the hash of the last changed
+// data. It must be called with b.mu locked.
+func (b *Broadcaster) updateHash(hash string) bool {
+	if hash == b.lastHash {
+		return false
+	}
+	b.lastHash = hash
+	return true
+}
+
+// hashOf returns the hex encoded SHA-256 hash of the JSON encoding of data
+func hashOf(data any) (string, error) {
 	bytes, err := json.Marshal(data)
 	if err != nil {
-		b.lc.Errorf("sse: Failed to marshal data for hash comparison: %v", err)
-		return false
+		return "", err
 	}
 
 	hashBytes := sha256.Sum256(bytes)
-	newHashStr := hex.EncodeToString(hashBytes[:])
-
-	return b.lastHash.CompareAndSwap(newHashStr)
+	return hex.EncodeToString(hashBytes[:]), nil
 }
@@ -1,13 +1,18 @@
 //
-// Copyright (C) 2025 IOTech Ltd
+// Copyright (C) 2025-2026 IOTech Ltd
 //
 
 package sse
 
 import (
-	"github.com/stretchr/testify/require"
+	"context"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
 	loggerMocks "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/mocks"
 )
 
@@ -40,16 +45,242 @@ func TestShouldSendUpdate(t *testing.T) {
 		{"map key order different", map[string]any{"x": 1, "y": "a"}, map[string]any{"y": "a", "x": 1}, false},
 	}
 
+	shouldSendUpdate := func(t *testing.T, b *Broadcaster, data any) bool {
+		hash, err := hashOf(data)
+		require.NoError(t, err)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.updateHash(hash)
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			b := NewBroadcaster(mockLogger)
-			require.True(t, b.shouldSendUpdate(tt.value1), "first update should always return true")
+			require.True(t, shouldSendUpdate(t, b, tt.value1), "first update should always return true")
 
 			if tt.expected {
-				require.True(t, b.shouldSendUpdate(tt.value2), "second comparison should return true (different)")
+				require.True(t, shouldSendUpdate(t, b, tt.value2), "second comparison should return true (different)")
 			} else {
-				require.False(t, b.shouldSendUpdate(tt.value2), "second comparison should return false (equal)")
+				require.False(t, shouldSendUpdate(t, b, tt.value2), "second comparison should return false (equal)")
 			}
 		})
 	}
 }
+
+func receiveEvent(t *testing.T, ch SubscriberCh) Event {
+	select {
+	case msg := <-ch:
+		event, ok := msg.(Event)
+		require.True(t, ok, "expected Event")
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "no event received within timeout")
+		return Event{}
+	}
+}
+
+func TestPublishAssignsEventIDs(t *testing.T) {
+	b := NewBroadcaster(log.NewNopeLogger())
+	ch := b.Subscribe(WithEvents())
+	defer b.Unsubscribe(ch)
+
+	b.Publish("a")
+	b.Publish("a") // unchanged data doesn't produce a new event
+	b.Publish("b")
+
+	assert.Equal(t, Event{ID: 1, Data: "a"}, receiveEvent(t, ch))
+	assert.Equal(t, Event{ID: 2, Data: "b"}, receiveEvent(t, ch))
+
+	// A new subscriber receives the latest event with its original ID
+	newCh := b.Subscribe(WithEvents())
+	defer b.Unsubscribe(newCh)
+	b.Publish("b")
+	assert.Equal(t, Event{ID: 2, Data: "b"}, receiveEvent(t, newCh))
+	assert.Empty(t, ch)
+}
+
+func TestSubscribeReplaysHistory(t *testing.T) {
+	b := NewBroadcaster(log.NewNopeLogger())
+	b.SetEventHistorySize(3)
+	for _, data := range []string{"a", "b", "c", "d", "e"} {
+		b.Publish(data)
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID uint64
+		expected    []Event
+	}{
+		{"replay after last event ID", 3, []Event{{ID: 4, Data: "d"}, {ID: 5, Data: "e"}}},
+		{"replay all buffered events if too old", 1, []Event{{ID: 3, Data: "c"}, {ID: 4, Data: "d"}, {ID: 5, Data: "e"}}},
+		{"nothing to replay if up-to-date", 5, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := b.Subscribe(WithEvents(), WithLastEventID(tt.lastEventID))
+			defer b.Unsubscribe(ch)
+			for _, expected := range tt.expected {
+				assert.Equal(t, expected, receiveEvent(t, ch))
+			}
+
+			// The replayed subscriber isn't sent the latest event again
+			b.Publish("e")
+			assert.Empty(t, ch)
+		})
+	}
+}
+
+func TestSubscribeEventIDFromEarlierSequence(t *testing.T) {
+	b := NewBroadcaster(log.NewNopeLogger())
+	b.Publish("a")
+
+	// The event ID newer than the latest event doesn't hide the latest event from the subscriber
+	ch := b.Subscribe(WithEvents(), WithLastEventID(5), WithLatestEvent())
+	defer b.Unsubscribe(ch)
+	assert.Equal(t, Event{ID: 1, Data: "a"}, receiveEvent(t, ch))
+}
+
+func TestSubscribeDeliversData(t *testing.T) {
+	b := NewBroadcaster(log.NewNopeLogger())
+	ch := b.Subscribe()
+	defer b.Unsubscribe(ch)
+
+	b.PublishEvent(EventTypeError, "failed")
+	select {
+	case msg := <-ch:
+		assert.Equal(t, "failed", msg)
+	case <-time.After(time.Second):
+		require.Fail(t, "no data received within timeout")
+	}
+}
+
+func TestSubscribeReplayDisabled(t *testing.T) {
+	b := NewBroadcaster(log.NewNopeLogger())
+	b.SetEventHistorySize(0)
+	b.Publish("a")
+	b.Publish("b")
+
+	ch := b.Subscribe(WithEvents(), WithLastEventID(1))
+	defer b.Unsubscribe(ch)
+	assert.Empty(t, ch)
+
+	// Without replay, the subscriber is sent the latest event as a new subscriber
+	b.Publish("b")
+	assert.Equal(t, Event{ID: 2, Data: "b"}, receiveEvent(t, ch))
+}
+
+func TestPublishPerSubscriberTransform(t *testing.T) {
+	b := NewBroadcaster(log.NewNopeLogger())
+	selectKey := func(key string) TransformFunc {
+		return func(_ string, data any) (any, bool) {
+			value, ok := data.(map[string]int)[key]
+			return value, ok
+		}
+	}
+	chA := b.Subscribe(WithEvents(), WithTransform(selectKey("a")))
+	defer b.Unsubscribe(chA)
+	chB := b.Subscribe(WithEvents(), WithTransform(selectKey("b")))
+	defer b.Unsubscribe(chB)
+	ch := b.Subscribe(WithEvents())
+	defer b.Unsubscribe(ch)
+
+	b.Publish(map[string]int{"a": 1, "b": 1})
+	b.Publish(map[string]int{"a": 1, "b": 2})
+	b.Publish(map[string]int{"a": 2})
+
+	assert.Equal(t, Event{ID: 1, Data: 1}, receiveEvent(t, chA))
+	// The projection of event 2 is unchanged for subscriber a
+	assert.Equal(t, Event{ID: 3, Data: 2}, receiveEvent(t, chA))
+	assert.Empty(t, chA)
+
+	assert.Equal(t, Event{ID: 1, Data: 1}, receiveEvent(t, chB))
+	assert.Equal(t, Event{ID: 2, Data: 2}, receiveEvent(t, chB))
+	// Event 3 is filtered out for subscriber b
+	assert.Empty(t, chB)
+
+	for i := uint64(1); i <= 3; i++ {
+		assert.Equal(t, i, receiveEvent(t, ch).ID)
+	}
+}
+
+func TestSubscriberOverflowPolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          OverflowPolicy
+		expectedIDs     []uint64
+		expectedDropped uint64
+		disconnected    bool
+	}{
+		{"drop newest", OverflowDropNewest, []uint64{1, 2}, 3, false},
+		{"drop oldest", OverflowDropOldest, []uint64{4, 5}, 3, false},
+		{"latest only", OverflowLatestOnly, []uint64{5}, 4, false},
+		{"disconnect", OverflowDisconnect, []uint64{1, 2}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBroadcaster(log.NewNopeLogger())
+			ch := b.Subscribe(WithEvents(), WithSubscriberBuffer(2, tt.policy))
+			defer b.Unsubscribe(ch)
+
+			for i := 1; i <= 5; i++ {
+				b.Publish(i)
+			}
+			assert.Equal(t, tt.expectedDropped, b.Dropped(ch))
+
+			for _, id := range tt.expectedIDs {
+				assert.Equal(t, id, receiveEvent(t, ch).ID)
+			}
+			if tt.disconnected {
+				_, ok := <-ch
+				assert.False(t, ok, "the channel of the slow subscriber should be closed")
+				b.mu.RLock()
+				assert.Empty(t, b.subscribers)
+				b.mu.RUnlock()
+			} else {
+				assert.Empty(t, ch)
+			}
+		})
+	}
+}
+
+func TestReconnectAfterOverflowDisconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	b, _ := m.CreateOrGetBroadcaster(testTopic)
+	ch := b.Subscribe(WithEvents(), WithSubscriberBuffer(1, OverflowDisconnect))
+
+	// The only subscriber is disconnected, so that the broadcaster is removed
+	b.Publish("a")
+	b.Publish("b")
+	assert.Equal(t, Event{ID: 1, Data: "a"}, receiveEvent(t, ch))
+	_, ok := <-ch
+	require.False(t, ok, "the channel of the slow subscriber should be closed")
+	require.Eventually(t, func() bool {
+		_, ok := m.GetBroadcaster(testTopic)
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// The reconnecting subscriber is replayed the events it missed, and the event IDs continue
+	b, _ = m.CreateOrGetBroadcaster(testTopic)
+	ch = b.Subscribe(WithEvents(), WithLastEventID(1), WithSubscriberBuffer(1, OverflowDisconnect))
+	defer b.Unsubscribe(ch)
+	assert.Equal(t, Event{ID: 2, Data: "b"}, receiveEvent(t, ch))
+	b.Publish("c")
+	assert.Equal(t, Event{ID: 3, Data: "c"}, receiveEvent(t, ch))
+}
+
+func TestReplayExceedingSubscriberBuffer(t *testing.T) {
+	b := NewBroadcaster(log.NewNopeLogger())
+	for _, data := range []string{"a", "b", "c"} {
+		b.Publish(data)
+	}
+
+	// The replayed events don't overflow the buffer of the reconnecting subscriber
+	ch := b.Subscribe(WithEvents(), WithLastEventID(1), WithSubscriberBuffer(1, OverflowDisconnect))
+	defer b.Unsubscribe(ch)
+	assert.Equal(t, Event{ID: 2, Data: "b"}, receiveEvent(t, ch))
+	assert.Equal(t, Event{ID: 3, Data: "c"}, receiveEvent(t, ch))
+	assert.Zero(t, b.Dropped(ch))
+}
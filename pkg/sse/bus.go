@@ -0,0 +1,99 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// BusMessage is a publication of a topic relayed across the instances of a service.
+type BusMessage struct {
+	// Origin is the ID of the Manager publishing the message, so that a Manager skips its own messages
+	Origin string          `json:"origin"`
+	Topic  string          `json:"topic"`
+	Type   string          `json:"type,omitempty"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Bus relays the publications of the topics across the instances of a service, e.g. the replicas behind a load
+// balancer, so that a publication on an instance reaches the subscribers on the other instances. The messages
+// published to a Bus must be delivered to the handlers of the topic on all the instances, including the publishing
+// one.
+type Bus interface {
+	// Publish relays the message to the handlers of its topic.
+	Publish(msg BusMessage) error
+	// Subscribe registers a handler of the messages of the topic, and returns a function to cancel the subscription.
+	// The handler must not block, as it may be called in the goroutine delivering the messages.
+	Subscribe(topic string, handler func(msg BusMessage)) (cancel func(), err error)
+}
+
+// busHandlers holds the handlers of the topics subscribed from a Bus
+type busHandlers struct {
+	mu       sync.RWMutex
+	handlers map[string]map[uint64]func(msg BusMessage)
+	nextID   uint64
+}
+
+func newBusHandlers() *busHandlers {
+	return &busHandlers{handlers: make(map[string]map[uint64]func(msg BusMessage))}
+}
+
+func (h *busHandlers) add(topic string, handler func(msg BusMessage)) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	id := h.nextID
+	if h.handlers[topic] == nil {
+		h.handlers[topic] = make(map[uint64]func(msg BusMessage))
+	}
+	h.handlers[topic][id] = handler
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.handlers[topic], id)
+		if len(h.handlers[topic]) == 0 {
+			delete(h.handlers, topic)
+		}
+	}
+}
+
+func (h *busHandlers) dispatch(msg BusMessage) {
+	h.mu.RLock()
+	handlers := make([]func(msg BusMessage), 0, len(h.handlers[msg.Topic]))
+	for _, handler := range h.handlers[msg.Topic] {
+		handlers = append(handlers, handler)
+	}
+	h.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(msg)
+	}
+}
+
+// MemoryBus is an in-process Bus, which relays the messages between the Managers sharing it. It is the reference
+// implementation of Bus, mainly for tests.
+type MemoryBus struct {
+	handlers *busHandlers
+}
+
+// NewMemoryBus creates a new instance of MemoryBus.
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{handlers: newBusHandlers()}
+}
+
+// Publish delivers the message to the handlers of its topic synchronously.
+func (b *MemoryBus) Publish(msg BusMessage) error {
+	b.handlers.dispatch(msg)
+	return nil
+}
+
+// Subscribe registers a handler of the messages of the topic.
+func (b *MemoryBus) Subscribe(topic string, handler func(msg BusMessage)) (func(), error) {
+	return b.handlers.add(topic, handler), nil
+}
@@ -0,0 +1,267 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+// subscribeTopic subscribes the topic of the Manager as the SSE handler does
+func subscribeTopic(t *testing.T, m *Manager, topic string) (*Broadcaster, SubscriberCh) {
+	b, _ := m.CreateOrGetBroadcaster(topic)
+	ch := b.Subscribe(WithEvents())
+	t.Cleanup(func() { b.Unsubscribe(ch) })
+	return b, ch
+}
+
+func TestManagerWithMemoryBus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := NewMemoryBus()
+	m1 := NewManager(ctx, log.NewNopeLogger(), time.Minute, WithBus(bus))
+	m2 := NewManager(ctx, log.NewNopeLogger(), time.Minute, WithBus(bus))
+
+	b1, ch1 := subscribeTopic(t, m1, testTopic)
+	_, ch2 := subscribeTopic(t, m2, testTopic)
+	_, other := subscribeTopic(t, m2, "other")
+
+	b1.Publish(map[string]int{"count": 1})
+	assert.Equal(t, Event{ID: 1, Data: map[string]int{"count": 1}}, receiveEvent(t, ch1))
+	event := receiveEvent(t, ch2)
+	assert.Equal(t, uint64(1), event.ID)
+	assert.JSONEq(t, `{"count":1}`, string(event.Data.(json.RawMessage)))
+
+	// The unchanged data isn't relayed, and the relayed data isn't relayed back
+	b1.Publish(map[string]int{"count": 1})
+	assert.Empty(t, ch1)
+	assert.Empty(t, ch2)
+	assert.Empty(t, other)
+}
+
+func TestManagerConcurrentCreateWithBus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := NewMemoryBus()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute, WithBus(bus))
+
+	var wg sync.WaitGroup
+	created := make(chan *Broadcaster, 10)
+	for i := 0; i < cap(created); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b, _ := m.CreateOrGetBroadcaster(testTopic)
+			created <- b
+		}()
+	}
+	wg.Wait()
+	close(created)
+
+	// All the goroutines share the same broadcaster, which subscribes the topic from the bus once
+	b, ok := m.GetBroadcaster(testTopic)
+	require.True(t, ok)
+	for other := range created {
+		assert.Same(t, b, other)
+	}
+	bus.handlers.mu.RLock()
+	assert.Len(t, bus.handlers.handlers[testTopic], 1)
+	bus.handlers.mu.RUnlock()
+}
+
+func TestSocketBus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := SocketBusConfig{
+		Network:           SocketBusNetworkUnix,
+		Address:           filepath.Join(t.TempDir(), "sse.sock"),
+		ReconnectInterval: 10 * time.Millisecond,
+	}
+
+	var managers []*Manager
+	var buses []*SocketBus
+	for i := 0; i < 3; i++ {
+		bus, err := NewSocketBus(ctx, log.NewNopeLogger(), config)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = bus.Close() })
+		buses = append(buses, bus)
+		managers = append(managers, NewManager(ctx, log.NewNopeLogger(), time.Minute, WithBus(bus)))
+	}
+	// The processes are connected in a star, i.e. the hub is connected to the others
+	connected := func(buses ...*SocketBus) bool {
+		total := 0
+		for _, bus := range buses {
+			bus.mu.Lock()
+			peers := len(bus.peers)
+			bus.mu.Unlock()
+			if peers == 0 {
+				return false
+			}
+			total += peers
+		}
+		return total == 2*(len(buses)-1)
+	}
+	require.Eventually(t, func() bool { return connected(buses...) }, 5*time.Second, 10*time.Millisecond)
+
+	var broadcasters []*Broadcaster
+	var channels []SubscriberCh
+	for _, m := range managers {
+		b, ch := subscribeTopic(t, m, testTopic)
+		broadcasters = append(broadcasters, b)
+		channels = append(channels, ch)
+	}
+
+	// A publication on any process reaches the subscribers of all the processes
+	for i, b := range broadcasters {
+		b.Publish(i)
+		for _, ch := range channels {
+			event := receiveEvent(t, ch)
+			assert.Equal(t, uint64(i+1), event.ID)
+			data, err := json.Marshal(event.Data)
+			require.NoError(t, err)
+			assert.JSONEq(t, strconv.Itoa(i), string(data))
+		}
+	}
+
+	// The remaining processes are reconnected once a process exits
+	require.NoError(t, buses[0].Close())
+	require.Eventually(t, func() bool {
+		return connected(buses[1:]...)
+	}, 5*time.Second, 10*time.Millisecond)
+	broadcasters[1].Publish("after")
+	event := receiveEvent(t, channels[2])
+	assert.Equal(t, json.RawMessage(`"after"`), event.Data)
+}
+
+// waitForPeers waits for the SocketBus to be connected to the number of the processes
+func waitForPeers(t *testing.T, bus *SocketBus, expected int) {
+	require.Eventually(t, func() bool {
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+		return len(bus.peers) == expected
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSocketBusStuckPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := SocketBusConfig{
+		Network:           SocketBusNetworkUnix,
+		Address:           filepath.Join(t.TempDir(), "sse.sock"),
+		ReconnectInterval: 10 * time.Millisecond,
+		WriteTimeout:      time.Minute,
+		SendQueueSize:     1,
+	}
+	bus, err := NewSocketBus(ctx, log.NewNopeLogger(), config)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = bus.Close() })
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(config.Address)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The process connected to the hub never reads the messages
+	conn, err := net.Dial(config.Network, config.Address)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	waitForPeers(t, bus, 1)
+
+	// Publishing isn't blocked by the stuck process, which is disconnected once its queue is full
+	data, err := json.Marshal(strings.Repeat("x", 64*1024))
+	require.NoError(t, err)
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		_ = bus.Publish(BusMessage{Topic: testTopic, Data: data})
+	}
+	assert.Less(t, time.Since(start), 5*time.Second)
+	waitForPeers(t, bus, 0)
+}
+
+func TestSocketBusStaleSocket(t *testing.T) {
+	address := filepath.Join(t.TempDir(), "sse.sock")
+	bus := &SocketBus{config: SocketBusConfig{Network: SocketBusNetworkUnix, Address: address}, lc: log.NewNopeLogger()}
+
+	// The socket left by a hub which exited abnormally
+	listener, err := net.Listen(SocketBusNetworkUnix, address)
+	require.NoError(t, err)
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, listener.Close())
+	stale, err := os.Stat(address)
+	require.NoError(t, err)
+
+	// The socket is replaced by a new hub before the removal
+	require.NoError(t, os.Remove(address))
+	listener, err = net.Listen(SocketBusNetworkUnix, address)
+	require.NoError(t, err)
+	bus.removeStaleSocket(stale)
+	_, err = os.Stat(address)
+	assert.NoError(t, err, "the socket of the new hub should be kept")
+
+	// The socket of the new hub is replaced once it exits abnormally as well
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, listener.Close())
+	stale, err = os.Stat(address)
+	require.NoError(t, err)
+	bus.removeStaleSocket(stale)
+	_, err = os.Stat(address)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSocketBusHubSocketReplaced(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := SocketBusConfig{
+		Network:           SocketBusNetworkUnix,
+		Address:           filepath.Join(t.TempDir(), "sse.sock"),
+		ReconnectInterval: 10 * time.Millisecond,
+	}
+	bus, err := NewSocketBus(ctx, log.NewNopeLogger(), config)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = bus.Close() })
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(config.Address)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Another process mistakes the socket of the hub for a stale one and becomes the hub
+	require.NoError(t, os.Remove(config.Address))
+	listener, err := net.Listen(config.Network, config.Address)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	// The former hub hands over and connects to the new hub
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+	select {
+	case conn := <-accepted:
+		_ = conn.Close()
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the former hub didn't connect to the new hub")
+	}
+}
+
+func TestNewSocketBusInvalidConfig(t *testing.T) {
+	_, err := NewSocketBus(context.Background(), log.NewNopeLogger(), SocketBusConfig{Network: "udp", Address: "127.0.0.1:0"})
+	assert.Error(t, err)
+	_, err = NewSocketBus(context.Background(), log.NewNopeLogger(), SocketBusConfig{Network: SocketBusNetworkTCP})
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DeltaMode defines how the updates are sent to a subscriber after the initial full snapshot.
+type DeltaMode string
+
+const (
+	// DeltaNone sends every update as a full snapshot. This is the default mode.
+	DeltaNone DeltaMode = ""
+	// DeltaJSONPatch sends the updates as RFC 6902 JSON Patch documents under the EventTypeJSONPatch event type.
+	DeltaJSONPatch DeltaMode = "json-patch"
+	// DeltaMergePatch sends the updates as RFC 7386 JSON Merge Patch documents under the EventTypeMergePatch event type.
+	DeltaMergePatch DeltaMode = "merge-patch"
+)
+
+// Event types of the delta updates, which are applied to the last full snapshot or delta update of the default
+// message type received by the client
+const (
+	EventTypeJSONPatch  = "json-patch"
+	EventTypeMergePatch = "merge-patch"
+)
+
+// patchOperation is an operation of RFC 6902 JSON Patch
+type patchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// deltaEncoder encodes the payloads of a subscriber as delta updates against the previous payload. It is owned by
+// the goroutine writing the events of the subscriber, so that the delta updates are always computed against the
+// payload which is actually written to the client regardless of the messages dropped for the subscriber.
+type deltaEncoder struct {
+	mode DeltaMode
+	// previous is the last payload written to the client, nil until the initial full snapshot is written
+	previous []byte
+}
+
+// encode returns the event type and the data to write for the payload of an event of the default message type.
+// The payload is written as a full snapshot if it is the first payload or the delta update isn't smaller.
+func (d *deltaEncoder) encode(payload []byte) (string, []byte) {
+	if d.mode == DeltaNone {
+		return "", payload
+	}
+	previous := d.previous
+	d.previous = payload
+	if previous == nil {
+		return "", payload
+	}
+
+	var (
+		eventType string
+		patch     []byte
+		err       error
+	)
+	switch d.mode {
+	case DeltaJSONPatch:
+		eventType = EventTypeJSONPatch
+		patch, err = createJSONPatch(previous, payload)
+	case DeltaMergePatch:
+		eventType = EventTypeMergePatch
+		patch, err = createMergePatch(previous, payload)
+	default:
+		return "", payload
+	}
+	if err != nil || len(patch) >= len(payload) {
+		return "", payload
+	}
+	return eventType, patch
+}
+
+// reset forgets the previous payload, so that the next payload is written as a full snapshot, e.g. after the previous
+// payload isn't written to the client as is.
+func (d *deltaEncoder) reset() {
+	d.previous = nil
+}
+
+// createJSONPatch creates the RFC 6902 JSON Patch transforming the original JSON document into the modified one.
+// The arrays of different lengths are replaced as a whole.
+func createJSONPatch(original, modified []byte) ([]byte, error) {
+	o, err := decodeJSON(original)
+	if err != nil {
+		return nil, err
+	}
+	m, err := decodeJSON(modified)
+	if err != nil {
+		return nil, err
+	}
+	operations := diffJSON("", o, m, []patchOperation{})
+	return json.Marshal(operations)
+}
+
+func diffJSON(path string, original, modified any, operations []patchOperation) []patchOperation {
+	if reflect.DeepEqual(original, modified) {
+		return operations
+	}
+
+	switch m := modified.(type) {
+	case map[string]any:
+		o, ok := original.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, key := range sortedKeys(o) {
+			if _, ok := m[key]; !ok {
+				operations = append(operations, patchOperation{Op: "remove", Path: path + "/" + escapePointer(key)})
+			}
+		}
+		for _, key := range sortedKeys(m) {
+			keyPath := path + "/" + escapePointer(key)
+			if value, ok := o[key]; ok {
+				operations = diffJSON(keyPath, value, m[key], operations)
+			} else {
+				operations = append(operations, addOperation("add", keyPath, m[key]))
+			}
+		}
+		return operations
+	case []any:
+		o, ok := original.([]any)
+		if !ok || len(o) != len(m) {
+			break
+		}
+		for i := range m {
+			operations = diffJSON(path+"/"+strconv.Itoa(i), o[i], m[i], operations)
+		}
+		return operations
+	}
+
+	return append(operations, addOperation("replace", path, modified))
+}
+
+// addOperation creates an operation with a value, which is kept in the JSON output even if it is null
+func addOperation(op, path string, value any) patchOperation {
+	if value == nil {
+		value = json.RawMessage("null")
+	}
+	return patchOperation{Op: op, Path: path, Value: value}
+}
+
+// createMergePatch creates the RFC 7386 JSON Merge Patch transforming the original JSON document into the modified
+// one. An error is returned if the modified document can't be represented by a merge patch, i.e. it sets a member
+// to null, which means removing the member in a merge patch.
+func createMergePatch(original, modified []byte) ([]byte, error) {
+	o, err := decodeJSON(original)
+	if err != nil {
+		return nil, err
+	}
+	m, err := decodeJSON(modified)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := diffMerge(o, m)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(patch)
+}
+
+func diffMerge(original, modified any) (any, error) {
+	o, isObject := original.(map[string]any)
+	m, ok := modified.(map[string]any)
+	if !isObject || !ok {
+		// The modified document replaces the original one as a whole, unless it contains null members
+		if containsNullMember(modified) {
+			return nil, errNullMember
+		}
+		return modified, nil
+	}
+
+	patch := make(map[string]any)
+	for key := range o {
+		if _, ok := m[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	for key, value := range m {
+		if reflect.DeepEqual(o[key], value) {
+			continue
+		}
+		if value == nil {
+			return nil, errNullMember
+		}
+		memberPatch, err := diffMerge(o[key], value)
+		if err != nil {
+			return nil, err
+		}
+		patch[key] = memberPatch
+	}
+	return patch, nil
+}
+
+var errNullMember = errors.New("null member can't be represented by a merge patch")
+
+func containsNullMember(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, member := range v {
+			if member == nil || containsNullMember(member) {
+				return true
+			}
+		}
+	case []any:
+		// The arrays are replaced as a whole, so the null elements are kept
+		for _, element := range v {
+			if containsNullMember(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decodeJSON decodes a JSON document with the numbers kept as json.Number, so that they are compared exactly
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a reference token of RFC 6901 JSON Pointer
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
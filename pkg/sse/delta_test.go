@@ -0,0 +1,106 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+func TestCreateJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		modified string
+		expected string
+	}{
+		{"unchanged", `{"a":1}`, `{"a":1}`, `[]`},
+		{"replace member", `{"a":1,"b":2}`, `{"a":1,"b":3}`, `[{"op":"replace","path":"/b","value":3}]`},
+		{"add and remove members", `{"a":1,"b":2}`, `{"a":1,"c":null}`, `[{"op":"remove","path":"/b"},{"op":"add","path":"/c","value":null}]`},
+		{"nested member", `{"a":{"b":{"c":1}}}`, `{"a":{"b":{"c":false}}}`, `[{"op":"replace","path":"/a/b/c","value":false}]`},
+		{"array element", `{"devices":[{"id":1},{"id":2}]}`, `{"devices":[{"id":1},{"id":3}]}`, `[{"op":"replace","path":"/devices/1/id","value":3}]`},
+		{"array length changed", `[1,2]`, `[1,2,3]`, `[{"op":"replace","path":"","value":[1,2,3]}]`},
+		{"escaped member names", `{"a/b":1,"c~d":1}`, `{"a/b":2,"c~d":2}`, `[{"op":"replace","path":"/a~1b","value":2},{"op":"replace","path":"/c~0d","value":2}]`},
+		{"type changed", `{"a":"1"}`, `{"a":1}`, `[{"op":"replace","path":"/a","value":1}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := createJSONPatch([]byte(tt.original), []byte(tt.modified))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(patch))
+		})
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		modified string
+		expected string
+	}{
+		{"unchanged", `{"a":1}`, `{"a":1}`, `{}`},
+		{"replace member", `{"a":1,"b":2}`, `{"a":1,"b":3}`, `{"b":3}`},
+		{"add and remove members", `{"a":1,"b":2}`, `{"a":1,"c":3}`, `{"b":null,"c":3}`},
+		{"nested member", `{"a":{"b":1,"c":1}}`, `{"a":{"b":1,"c":2}}`, `{"a":{"c":2}}`},
+		{"array replaced", `{"a":[1,2]}`, `{"a":[1,null]}`, `{"a":[1,null]}`},
+		{"non-object document", `[1]`, `[2]`, `[2]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := createMergePatch([]byte(tt.original), []byte(tt.modified))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(patch))
+		})
+	}
+
+	// A null member can't be represented by a merge patch
+	_, err := createMergePatch([]byte(`{"a":1}`), []byte(`{"a":null}`))
+	assert.Error(t, err)
+	_, err = createMergePatch([]byte(`{"a":1}`), []byte(`{"a":{"b":null}}`))
+	assert.Error(t, err)
+}
+
+func TestDeltaEncoder(t *testing.T) {
+	d := &deltaEncoder{mode: DeltaMergePatch}
+	large := `{"name":"device","description":"a device with a long description","value":1}`
+
+	eventType, data := d.encode([]byte(large))
+	assert.Empty(t, eventType, "the initial payload should be a full snapshot")
+	assert.Equal(t, large, string(data))
+
+	eventType, data = d.encode([]byte(`{"name":"device","description":"a device with a long description","value":2}`))
+	assert.Equal(t, EventTypeMergePatch, eventType)
+	assert.JSONEq(t, `{"value":2}`, string(data))
+
+	// The payload is sent as a full snapshot if the delta isn't smaller
+	eventType, data = d.encode([]byte(`{"x":1}`))
+	assert.Empty(t, eventType)
+	assert.Equal(t, `{"x":1}`, string(data))
+}
+
+func TestHandlerDeltaMode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithDeltaMode(DeltaJSONPatch))
+
+	stream := openStream(t, server.URL+"/sse", nil)
+	b := waitForSubscribers(t, m, testTopic, 1)
+	b.Publish([]map[string]any{{"name": "device-1", "state": "UNLOCKED"}, {"name": "device-2", "state": "UNLOCKED"}})
+	b.Publish([]map[string]any{{"name": "device-1", "state": "UNLOCKED"}, {"name": "device-2", "state": "LOCKED"}})
+	b.PublishEvent(EventTypeError, "failed")
+
+	assert.Equal(t, map[string]string{"id": "1", "data": `[{"name":"device-1","state":"UNLOCKED"},{"name":"device-2","state":"UNLOCKED"}]`}, stream.next(t))
+	assert.Equal(t, map[string]string{"id": "2", "event": EventTypeJSONPatch, "data": `[{"op":"replace","path":"/1/state","value":"LOCKED"}]`}, stream.next(t))
+	// The other event types are sent as is
+	assert.Equal(t, map[string]string{"id": "3", "event": EventTypeError, "data": `"failed"`}, stream.next(t))
+}
@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+// OversizedEventPolicy defines how an event is handled if its data exceeds the max event size.
+type OversizedEventPolicy string
+
+const (
+	// OversizedEventReject replaces the oversized event with an event of the EventTypeError event type carrying a
+	// 413 error response. This is the default policy.
+	OversizedEventReject OversizedEventPolicy = "reject"
+	// OversizedEventTruncate truncates the data of the oversized event to the max event size, which is sent under the
+	// EventTypeTruncated event type as it is no longer valid JSON.
+	OversizedEventTruncate OversizedEventPolicy = "truncate"
+)
+
+// EventTypeTruncated is the event type of the data truncated by OversizedEventTruncate
+const EventTypeTruncated = "truncated"
+
+// limitEventSize returns the event and data to write in place of the event whose data exceeds the max event size,
+// and false if the data doesn't exceed it. The ID of the event is kept, so that the client doesn't have the event
+// replayed once it reconnects.
+func (config *HandlerConfig) limitEventSize(event Event, data []byte) (Event, []byte, bool) {
+	if config.MaxEventSize <= 0 || len(data) <= config.MaxEventSize {
+		return event, data, false
+	}
+
+	if config.OversizedEventPolicy == OversizedEventTruncate {
+		// Truncate at a rune boundary, so that the truncated data is still valid UTF-8
+		size := config.MaxEventSize
+		for size > 0 && !utf8.RuneStart(data[size]) {
+			size--
+		}
+		return Event{ID: event.ID, Type: EventTypeTruncated}, data[:size], true
+	}
+
+	message := fmt.Sprintf("event of %d bytes exceeds the max event size of %d bytes", len(data), config.MaxEventSize)
+	// Marshalling a BaseResponse never fails
+	resp, _ := json.Marshal(rest.NewBaseResponse(common.ApiVersion, "", message, http.StatusRequestEntityTooLarge))
+	return Event{ID: event.ID, Type: EventTypeError}, resp, true
+}
@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+func TestLimitEventSize(t *testing.T) {
+	event := Event{ID: 7}
+
+	config := &HandlerConfig{}
+	_, data, oversized := config.limitEventSize(event, []byte(`"unlimited"`))
+	assert.False(t, oversized)
+	assert.Equal(t, `"unlimited"`, string(data))
+
+	config = &HandlerConfig{MaxEventSize: 8, OversizedEventPolicy: OversizedEventTruncate}
+	_, data, oversized = config.limitEventSize(event, []byte(`"short"`))
+	assert.False(t, oversized)
+	assert.Equal(t, `"short"`, string(data))
+
+	limited, data, oversized := config.limitEventSize(event, []byte(`"truncated"`))
+	assert.True(t, oversized)
+	assert.Equal(t, Event{ID: 7, Type: EventTypeTruncated}, limited)
+	assert.Equal(t, `"truncat`, string(data))
+
+	// The data is truncated at a rune boundary
+	limited, data, oversized = config.limitEventSize(event, []byte(`"abcdef€"`))
+	assert.True(t, oversized)
+	assert.Equal(t, Event{ID: 7, Type: EventTypeTruncated}, limited)
+	assert.Equal(t, `"abcdef`, string(data))
+
+	config = &HandlerConfig{MaxEventSize: 8}
+	limited, data, oversized = config.limitEventSize(event, []byte(`"rejected"`))
+	assert.True(t, oversized)
+	assert.Equal(t, Event{ID: 7, Type: EventTypeError}, limited)
+	var resp rest.BaseResponse
+	require.NoError(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestHandlerMaxEventSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithDeltaMode(DeltaMergePatch),
+		WithMaxEventSize(64, OversizedEventReject))
+
+	stream := openStream(t, server.URL+"/sse", nil)
+	b := waitForSubscribers(t, m, testTopic, 1)
+	b.Publish(map[string]string{"name": "a"})
+	assert.Equal(t, map[string]string{"id": "1", "data": `{"name":"a"}`}, stream.next(t))
+
+	b.Publish(map[string]string{"name": "a", "extra": strings.Repeat("b", 100)})
+	event := stream.next(t)
+	assert.Equal(t, "2", event["id"])
+	assert.Equal(t, EventTypeError, event["event"])
+
+	// The next update is sent as a full snapshot rather than a merge patch against the oversized payload, as the
+	// client didn't receive it
+	b.Publish(map[string]string{"name": "a", "extra": "c", "more": strings.Repeat("d", 20)})
+	assert.Equal(t, map[string]string{"id": "3", "data": `{"extra":"c","more":"dddddddddddddddddddd","name":"a"}`},
+		stream.next(t))
+}
@@ -1,23 +1,32 @@
 //
-// Copyright (C) 2025 IOTech Ltd
+// Copyright (C) 2025-2026 IOTech Ltd
 //
 
 package sse
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
 )
 
 const defaultHeartbeatInterval = 30 * time.Second
 
+// HeaderLastEventID is the header sent by the reconnecting clients with the ID of the last event they received
+const HeaderLastEventID = "Last-Event-ID"
+
 // Handler creates an SSE handler that listens for messages on a specific topic and sends the data to the client.
 // It can be configured with options such as a PollingService to periodically fetch data and publish it to subscribers.
+// The clients can be authorized with WithAuthorizer and capped with WithMaxConnectionsPerUser before subscribing.
 func Handler(m *Manager, opts ...HandlerOption) echo.HandlerFunc {
 	// Apply options to the HandlerConfig if provided
 	config := &HandlerConfig{}
@@ -37,18 +46,57 @@ func Handler(m *Manager, opts ...HandlerOption) echo.HandlerFunc {
 			m.lc.Debugf("sse: Creating SSE handler for topic '%s'", topic)
 		}
 
-		b, isNew := m.CreateOrGetBroadcaster(topic)
-		// Only set the PollingService if it is provided in the configuration and the broadcaster is new.
-		// Otherwise, the handler will just listen for messages without polling.
-		// That is, the user should publish messages through the broadcaster manually.
-		if config.PollingService != nil && isNew {
-			m.lc.Debugf("sse: Setting up polling service for topic '%s'", topic)
-			b.SetPollingService(config.PollingService)
-			b.StartPolling()
+		topic, release, err := config.admit(c, m, topic)
+		if err != nil {
+			return err
 		}
+		defer release()
+
+		b := config.setupBroadcaster(m, topic)
+		return handleSSE(c, m.ctx, b, m.heartbeatInterval, config)
+	}
+}
+
+// setupBroadcaster retrieves the broadcaster of the topic, or creates a new one set up with the configuration.
+func (config *HandlerConfig) setupBroadcaster(m *Manager, topic string) *Broadcaster {
+	b, isNew := m.CreateOrGetBroadcaster(topic)
+	if !isNew {
+		return b
+	}
+	if config.EventHistorySize != 0 {
+		b.SetEventHistorySize(config.EventHistorySize)
+	}
+	if config.IdleTimeout > 0 {
+		b.SetIdleTimeout(config.IdleTimeout)
+	}
 
-		return handleSSE(c, m.ctx, b, m.heartbeatInterval)
+	// Only set the PollingService if it is provided in the configuration and the broadcaster is new.
+	// Otherwise, the handler will just listen for messages without polling.
+	// That is, the user should publish messages through the broadcaster manually.
+	pollingService := config.PollingService
+	if config.PollingServiceFactory != nil {
+		pollingService = config.PollingServiceFactory(topic)
 	}
+	if pollingService != nil {
+		m.lc.Debugf("sse: Setting up polling service for topic '%s'", topic)
+		b.SetPollingService(pollingService)
+		b.StartPolling()
+	}
+	return b
+}
+
+// subscribeOptions returns the SubscribeOptions of the request of a client, which is reconnecting if lastEventID
+// isn't zero.
+func (config *HandlerConfig) subscribeOptions(c echo.Context, lastEventID uint64) []SubscribeOption {
+	opts := []SubscribeOption{
+		WithEvents(),
+		WithLastEventID(lastEventID),
+		WithSubscriberBuffer(config.SubscriberBufferSize, config.OverflowPolicy),
+	}
+	if config.SubscriberTransform != nil {
+		opts = append(opts, WithTransform(config.SubscriberTransform(c)))
+	}
+	return opts
 }
 
 // ConstructSSETopic constructs a unique topic string based on the request context.
@@ -61,8 +109,22 @@ func ConstructSSETopic(c echo.Context) string {
 	return c.Request().URL.Path + "?" + c.QueryString()
 }
 
-func handleSSE(c echo.Context, serviceCtx context.Context, b *Broadcaster, heartbeatInterval time.Duration) error {
-	ch := b.Subscribe()
+// lastEventID returns the ID of the last event received by the reconnecting client, zero if none or invalid.
+func lastEventID(c echo.Context, lc log.Logger) uint64 {
+	header := c.Request().Header.Get(HeaderLastEventID)
+	if header == "" {
+		return 0
+	}
+	id, err := strconv.ParseUint(header, 10, 64)
+	if err != nil {
+		lc.Debugf("sse: Ignoring invalid %s header '%s': %v", HeaderLastEventID, header, err)
+		return 0
+	}
+	return id
+}
+
+func handleSSE(c echo.Context, serviceCtx context.Context, b *Broadcaster, heartbeatInterval time.Duration, config *HandlerConfig) error {
+	ch := b.Subscribe(config.subscribeOptions(c, lastEventID(c, b.lc))...)
 	defer b.Unsubscribe(ch)
 
 	// Force any pending HTTP headers (such as "Content-Type: text/event-stream")
@@ -71,14 +133,28 @@ func handleSSE(c echo.Context, serviceCtx context.Context, b *Broadcaster, heart
 	// and some clients will not start processing events until the headers
 	// have actually been received.
 	setSSEHeaders(c)
-	if f, ok := c.Response().Writer.(http.Flusher); ok {
-		f.Flush()
-	} else {
+	stream := newEventStream(c, config.Compression)
+	defer func() {
+		if err := stream.close(); err != nil {
+			b.lc.Debugf("sse: failed to close the compressed stream: %v", err)
+		}
+	}()
+	if config.RetryInterval > 0 {
+		if _, err := fmt.Fprintf(stream, "retry: %d\n\n", config.RetryInterval.Milliseconds()); err != nil {
+			b.lc.Errorf("failed to write retry interval: %v", err)
+			return nil
+		}
+	}
+	if _, ok := c.Response().Writer.(http.Flusher); !ok {
 		// In normal Echo deployments, c.Response().Writer implements http.Flusher,
 		// so flushing will work. This check is mainly for tests or custom middlewares
 		// that may wrap the ResponseWriter without flushing support.
 		b.lc.Warn("sse: ResponseWriter does not support flushing, SSE may not work as expected")
 	}
+	if err := stream.flush(); err != nil {
+		b.lc.Errorf("sse: failed to flush the stream: %v", err)
+		return nil
+	}
 
 	// Fallback to the default heartbeat interval if it is unset or invalid.
 	if heartbeatInterval <= 0 {
@@ -94,14 +170,32 @@ func handleSSE(c echo.Context, serviceCtx context.Context, b *Broadcaster, heart
 	// the write will fail, allowing us to detect broken or extremely slow connections sooner.
 	rc := http.NewResponseController(c.Response().Writer)
 
+	// The delta updates are computed against the payload written to this client
+	delta := &deltaEncoder{mode: config.DeltaMode}
+
 	for {
 		select {
-		case msg := <-ch:
-			msgJSON, err := json.Marshal(msg)
+		case msg, ok := <-ch:
+			if !ok {
+				// The subscriber has been disconnected by the overflow policy, so that the client reconnects
+				b.lc.Debug("sse: Subscriber is too slow, closing the SSE connection")
+				return nil
+			}
+			event := msg.(Event)
+			msgJSON, err := json.Marshal(event.Data)
 			if err != nil {
 				b.lc.Errorf("failed to serialize message: %v", err)
 				continue
 			}
+			if event.Type == "" {
+				event.Type, msgJSON = delta.encode(msgJSON)
+			}
+			var oversized bool
+			if event, msgJSON, oversized = config.limitEventSize(event, msgJSON); oversized {
+				b.lc.Debugf("sse: Event %d exceeds the max event size of %d bytes", event.ID, config.MaxEventSize)
+				// The client doesn't receive the payload as is, so the next update is sent as a full snapshot
+				delta.reset()
+			}
 
 			// Set a write deadline to avoid blocking indefinitely when writing
 			// to a slow or broken connection.
@@ -110,15 +204,16 @@ func handleSSE(c echo.Context, serviceCtx context.Context, b *Broadcaster, heart
 				return nil
 			}
 
-			_, err = fmt.Fprintf(c.Response().Writer, "data: %s\n\n", msgJSON)
+			_, err = writeEvent(stream, event, msgJSON)
+			if err == nil {
+				err = stream.flush()
+			}
 			if err != nil {
 				// If writing fails, log the error and close the connection.
 				b.lc.Errorf("failed to write message: %v", err)
 				return nil
 			}
 
-			c.Response().Flush()
-
 		case <-heartbeatTicker.C:
 			// Send a comment line as a heartbeat to keep the connection alive.
 			// Also set a write deadline to avoid blocking indefinitely.
@@ -127,15 +222,16 @@ func handleSSE(c echo.Context, serviceCtx context.Context, b *Broadcaster, heart
 				return nil
 			}
 
-			_, err := fmt.Fprintf(c.Response().Writer, ":\n\n")
+			_, err := fmt.Fprintf(stream, ":\n\n")
+			if err == nil {
+				err = stream.flush()
+			}
 			if err != nil {
 				// Log the error and exit the loop to clean up the connection
 				b.lc.Warnf("sse: heartbeat write failed: %v", err)
 				return nil
 			}
 
-			c.Response().Flush()
-
 		case <-c.Request().Context().Done():
 			// The client cancelled the request or the context timed out.
 			b.lc.Debug("sse: Request cancelled or timed out")
@@ -149,6 +245,21 @@ func handleSSE(c echo.Context, serviceCtx context.Context, b *Broadcaster, heart
 	}
 }
 
+// writeEvent writes an event with the serialized data in the SSE format. The id field is omitted if the event has no
+// ID, so that the client keeps the ID of the last event it received, and the event field is omitted for the default
+// message type.
+func writeEvent(w io.Writer, event Event, data []byte) (int, error) {
+	var buf bytes.Buffer
+	if event.ID != 0 {
+		fmt.Fprintf(&buf, "id: %d\n", event.ID)
+	}
+	if event.Type != "" {
+		fmt.Fprintf(&buf, "event: %s\n", event.Type)
+	}
+	fmt.Fprintf(&buf, "data: %s\n\n", data)
+	return w.Write(buf.Bytes())
+}
+
 func setSSEHeaders(c echo.Context) {
 	c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
 	c.Response().Header().Set("Cache-Control", "no-cache")
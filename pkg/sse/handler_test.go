@@ -0,0 +1,247 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+const testTopic = "test-topic"
+
+// newTestServer starts a server serving the SSE handler of the given Manager at /sse
+func newTestServer(t *testing.T, m *Manager, opts ...HandlerOption) *httptest.Server {
+	e := echo.New()
+	e.GET("/sse", Handler(m, opts...))
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// sseStream reads the events of an SSE response
+type sseStream struct {
+	resp    *http.Response
+	scanner *bufio.Scanner
+}
+
+func openStream(t *testing.T, url string, header http.Header) *sseStream {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return &sseStream{resp: resp, scanner: bufio.NewScanner(resp.Body)}
+}
+
+// next returns the fields of the next event, skipping the comments
+func (s *sseStream) next(t *testing.T) map[string]string {
+	fields := make(chan map[string]string, 1)
+	go func() {
+		event := map[string]string{}
+		for s.scanner.Scan() {
+			line := s.scanner.Text()
+			if line == "" {
+				if len(event) > 0 {
+					fields <- event
+					return
+				}
+				continue
+			}
+			if strings.HasPrefix(line, ":") {
+				continue
+			}
+			name, value, _ := strings.Cut(line, ":")
+			event[name] = strings.TrimPrefix(value, " ")
+		}
+		close(fields)
+	}()
+
+	select {
+	case event, ok := <-fields:
+		require.True(t, ok, "stream closed before receiving an event")
+		return event
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "no event received within timeout")
+		return nil
+	}
+}
+
+// waitForSubscribers waits until the broadcaster of the topic has the expected number of subscribers
+func waitForSubscribers(t *testing.T, m *Manager, topic string, expected int) *Broadcaster {
+	var b *Broadcaster
+	require.Eventually(t, func() bool {
+		var ok bool
+		b, ok = m.GetBroadcaster(topic)
+		if !ok {
+			return false
+		}
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		return len(b.subscribers) == expected
+	}, 2*time.Second, 10*time.Millisecond)
+	return b
+}
+
+func TestHandlerLastEventIDReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic))
+
+	stream := openStream(t, server.URL+"/sse", nil)
+	b := waitForSubscribers(t, m, testTopic, 1)
+	b.Publish(map[string]int{"count": 1})
+	b.Publish(map[string]int{"count": 2})
+	b.Publish(map[string]int{"count": 3})
+
+	assert.Equal(t, map[string]string{"id": "1", "data": `{"count":1}`}, stream.next(t))
+	assert.Equal(t, map[string]string{"id": "2", "data": `{"count":2}`}, stream.next(t))
+	assert.Equal(t, map[string]string{"id": "3", "data": `{"count":3}`}, stream.next(t))
+
+	// The reconnecting client is replayed the events it missed
+	reconnected := openStream(t, server.URL+"/sse", http.Header{HeaderLastEventID: []string{"1"}})
+	assert.Equal(t, map[string]string{"id": "2", "data": `{"count":2}`}, reconnected.next(t))
+	assert.Equal(t, map[string]string{"id": "3", "data": `{"count":3}`}, reconnected.next(t))
+}
+
+// waitForRemoval waits for the broadcaster of the topic to be removed after its last subscriber leaves
+func waitForRemoval(t *testing.T, m *Manager, topic string) {
+	require.Eventually(t, func() bool {
+		_, ok := m.GetBroadcaster(topic)
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestHandlerReplayAfterLastClientLeaves(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic))
+
+	stream := openStream(t, server.URL+"/sse", nil)
+	b := waitForSubscribers(t, m, testTopic, 1)
+	b.Publish(map[string]int{"count": 1})
+	b.Publish(map[string]int{"count": 2})
+	assert.Equal(t, map[string]string{"id": "1", "data": `{"count":1}`}, stream.next(t))
+
+	// The only client leaves, so that the broadcaster is removed
+	require.NoError(t, stream.resp.Body.Close())
+	waitForRemoval(t, m, testTopic)
+
+	// The reconnecting client is replayed the events it missed, and the event IDs continue
+	reconnected := openStream(t, server.URL+"/sse", http.Header{HeaderLastEventID: []string{"1"}})
+	assert.Equal(t, map[string]string{"id": "2", "data": `{"count":2}`}, reconnected.next(t))
+	b = waitForSubscribers(t, m, testTopic, 1)
+	b.Publish(map[string]int{"count": 2})
+	b.Publish(map[string]int{"count": 3})
+	assert.Equal(t, map[string]string{"id": "3", "data": `{"count":3}`}, reconnected.next(t))
+}
+
+func TestHandlerReconnectAfterEventRetention(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute, WithEventRetention(time.Millisecond))
+	server := newTestServer(t, m, WithCustomTopic(testTopic))
+
+	stream := openStream(t, server.URL+"/sse", nil)
+	b := waitForSubscribers(t, m, testTopic, 1)
+	b.Publish("a")
+	b.Publish("b")
+	assert.Equal(t, map[string]string{"id": "1", "data": `"a"`}, stream.next(t))
+	assert.Equal(t, map[string]string{"id": "2", "data": `"b"`}, stream.next(t))
+	require.NoError(t, stream.resp.Body.Close())
+	waitForRemoval(t, m, testTopic)
+	time.Sleep(10 * time.Millisecond)
+
+	// The event ID of the expired events is from an earlier sequence, so the client is handled as a new client
+	reconnected := openStream(t, server.URL+"/sse", http.Header{HeaderLastEventID: []string{"2"}})
+	b = waitForSubscribers(t, m, testTopic, 1)
+	b.Publish("c")
+	assert.Equal(t, map[string]string{"id": "1", "data": `"c"`}, reconnected.next(t))
+}
+
+func TestHandlerEventTypeAndRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithRetryInterval(3*time.Second))
+
+	stream := openStream(t, server.URL+"/sse", nil)
+	assert.Equal(t, map[string]string{"retry": "3000"}, stream.next(t))
+
+	b := waitForSubscribers(t, m, testTopic, 1)
+	b.PublishEvent(EventTypeError, "failed")
+	b.Publish("ok")
+	assert.Equal(t, map[string]string{"id": "1", "event": EventTypeError, "data": `"failed"`}, stream.next(t))
+	assert.Equal(t, map[string]string{"id": "2", "data": `"ok"`}, stream.next(t))
+}
+
+func TestHandlerSubscriberTransform(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithSubscriberTransform(func(c echo.Context) TransformFunc {
+		label := c.QueryParam("label")
+		return func(_ string, data any) (any, bool) {
+			var matched []string
+			for _, device := range data.([]string) {
+				if strings.HasPrefix(device, label) {
+					matched = append(matched, device)
+				}
+			}
+			return matched, true
+		}
+	}))
+
+	sensors := openStream(t, server.URL+"/sse?label=sensor", nil)
+	meters := openStream(t, server.URL+"/sse?label=meter", nil)
+	// Both clients share the broadcaster of the custom topic
+	b := waitForSubscribers(t, m, testTopic, 2)
+	b.Publish([]string{"sensor-1", "meter-1"})
+	b.Publish([]string{"sensor-1", "meter-2"})
+
+	assert.Equal(t, map[string]string{"id": "1", "data": `["sensor-1"]`}, sensors.next(t))
+	assert.Equal(t, map[string]string{"id": "1", "data": `["meter-1"]`}, meters.next(t))
+	assert.Equal(t, map[string]string{"id": "2", "data": `["meter-2"]`}, meters.next(t))
+}
+
+func TestHandlerDisconnectSlowSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithOverflowPolicy(OverflowDisconnect), WithSubscriberBufferSize(1))
+
+	stream := openStream(t, server.URL+"/sse", nil)
+	b := waitForSubscribers(t, m, testTopic, 1)
+	// Publish faster than the handler can drain the channel until the subscriber is disconnected
+	require.Eventually(t, func() bool {
+		for i := 0; i < 100; i++ {
+			b.Publish(strings.Repeat("x", 1024) + time.Now().String())
+		}
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		return len(b.subscribers) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// The stream ends once the pending events are written, so that the client reconnects
+	_, err := io.ReadAll(stream.resp.Body)
+	assert.NoError(t, err)
+}
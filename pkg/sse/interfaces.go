@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2025 IOTech Ltd
+// Copyright (C) 2025-2026 IOTech Ltd
 //
 
 package sse
@@ -9,6 +9,13 @@ type Publisher interface {
 	Publish(data any)
 }
 
+// EventPublisher is a Publisher which can also publish data under a named event type, which is sent as the event
+// field of SSE so that the clients can distinguish the kinds of messages.
+type EventPublisher interface {
+	Publisher
+	PublishEvent(eventType string, data any)
+}
+
 // PollingService is an interface for a service that periodically fetches data and publishes it to subscribers.
 type PollingService interface {
 	Start(publisher Publisher)
@@ -0,0 +1,162 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+const (
+	defaultLongPollingTimeout = 30 * time.Second
+	// defaultLongPollingIdleTimeout keeps the topic between the requests of the long-polling clients
+	defaultLongPollingIdleTimeout = time.Minute
+)
+
+// QueryLastEventID is the query parameter of the ID of the last event received by a long-polling client, which is
+// excluded from the topic. The Last-Event-ID header is also accepted.
+const QueryLastEventID = "lastEventId"
+
+// LongPollingEvent is an event in the response of LongPollingHandler.
+type LongPollingEvent struct {
+	ID    uint64 `json:"id"`
+	Event string `json:"event,omitempty"`
+	Data  any    `json:"data"`
+}
+
+// LongPollingResponse is the response of LongPollingHandler.
+type LongPollingResponse struct {
+	rest.BaseResponse `json:",inline"`
+	// Events are the events after the last event ID of the request in order, empty if the request times out
+	Events []LongPollingEvent `json:"events"`
+	// LastEventID is the ID of the last event received by the client, which should be passed in the next request
+	LastEventID uint64 `json:"lastEventId"`
+}
+
+// LongPollingHandler creates a long-polling handler over the same topics as Handler, as a fallback for the clients
+// behind the proxies which buffer the SSE streams. The client passes the ID of the last event it received with the
+// lastEventId query parameter or the Last-Event-ID header, and the request blocks until there are newer events or the
+// timeout elapses. A client without the last event ID receives the latest event of the topic.
+//
+// The options are shared with Handler except WithRetryInterval, WithDeltaMode, WithCompression and
+// WithMaxEventSize, as the responses can be compressed by the Gzip middleware of echo. The topics are kept for
+// defaultLongPollingIdleTimeout between the requests unless WithIdleTimeout is set, so that the clients share the
+// same polling and event history. This also applies to the topics created by the other handlers once a long-polling
+// client subscribes them.
+func LongPollingHandler(m *Manager, opts ...HandlerOption) echo.HandlerFunc {
+	// Apply options to the HandlerConfig if provided
+	config := &HandlerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = defaultLongPollingIdleTimeout
+	}
+	if config.LongPollingTimeout <= 0 {
+		config.LongPollingTimeout = defaultLongPollingTimeout
+	}
+
+	return func(c echo.Context) error {
+		topic := config.CustomTopic
+		if topic == "" {
+			topic = constructLongPollingTopic(c)
+		}
+		topic, release, err := config.admit(c, m, topic)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		b := config.setupBroadcaster(m, topic)
+		// The topic may have been created by another handler with a shorter idle timeout
+		b.extendIdleTimeout(config.IdleTimeout)
+
+		lastID := lastEventID(c, b.lc)
+		if query := c.QueryParam(QueryLastEventID); query != "" {
+			id, err := strconv.ParseUint(query, 10, 64)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, rest.NewBaseResponse(common.ApiVersion, "",
+					"invalid "+QueryLastEventID+" query parameter: "+err.Error(), http.StatusBadRequest))
+			}
+			lastID = id
+		}
+
+		ch := b.Subscribe(append(config.subscribeOptions(c, lastID), WithLatestEvent())...)
+		defer b.Unsubscribe(ch)
+
+		events := waitForEvents(c, m, ch, config.LongPollingTimeout)
+		resp := LongPollingResponse{
+			BaseResponse: rest.NewBaseResponse(common.ApiVersion, "", "", http.StatusOK),
+			Events:       events,
+			LastEventID:  lastID,
+		}
+		if len(events) > 0 {
+			resp.LastEventID = events[len(events)-1].ID
+		}
+		return c.JSON(http.StatusOK, resp)
+	}
+}
+
+// waitForEvents waits for the first event until the timeout elapses, and returns it along with the other events
+// which are already pending.
+func waitForEvents(c echo.Context, m *Manager, ch SubscriberCh, timeout time.Duration) []LongPollingEvent {
+	events := []LongPollingEvent{}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case msg, ok := <-ch:
+		if !ok {
+			return events
+		}
+		events = append(events, longPollingEvent(msg))
+	case <-timer.C:
+		return events
+	case <-c.Request().Context().Done():
+		return events
+	case <-m.ctx.Done():
+		return events
+	}
+
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, longPollingEvent(msg))
+		default:
+			return events
+		}
+	}
+}
+
+func longPollingEvent(msg any) LongPollingEvent {
+	event := msg.(Event)
+	return LongPollingEvent{ID: event.ID, Event: event.Type, Data: event.Data}
+}
+
+// constructLongPollingTopic constructs the same topic as ConstructSSETopic for the request of the long-polling
+// client, excluding the lastEventId query parameter.
+func constructLongPollingTopic(c echo.Context) string {
+	var params []string
+	for _, param := range strings.Split(c.QueryString(), "&") {
+		if param == "" || param == QueryLastEventID || strings.HasPrefix(param, QueryLastEventID+"=") {
+			continue
+		}
+		params = append(params, param)
+	}
+	if len(params) == 0 {
+		return c.Request().URL.Path
+	}
+	return c.Request().URL.Path + "?" + strings.Join(params, "&")
+}
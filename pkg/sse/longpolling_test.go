@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+func longPoll(t *testing.T, url string) LongPollingResponse {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var lpResp LongPollingResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&lpResp))
+	return lpResp
+}
+
+func TestLongPollingHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	e := echo.New()
+	e.GET("/poll", LongPollingHandler(m, WithLongPollingTimeout(200*time.Millisecond)))
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	// The topic is kept between the requests, so it can be published before the client subscribes
+	b, _ := m.CreateOrGetBroadcaster("/poll?label=a")
+	b.SetIdleTimeout(time.Minute)
+	b.Publish("a1")
+	b.Publish("a2")
+
+	// A client without the last event ID receives the latest event immediately
+	resp := longPoll(t, server.URL+"/poll?label=a")
+	assert.Equal(t, []LongPollingEvent{{ID: 2, Data: "a2"}}, resp.Events)
+	assert.Equal(t, uint64(2), resp.LastEventID)
+
+	// The request blocks until a newer event is published
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		b.PublishEvent(EventTypeError, "failed")
+	}()
+	resp = longPoll(t, server.URL+"/poll?lastEventId=2&label=a")
+	assert.Equal(t, []LongPollingEvent{{ID: 3, Event: EventTypeError, Data: "failed"}}, resp.Events)
+
+	// The missed events are returned together
+	resp = longPoll(t, server.URL+"/poll?label=a&lastEventId=1")
+	assert.Equal(t, []LongPollingEvent{{ID: 2, Data: "a2"}, {ID: 3, Event: EventTypeError, Data: "failed"}}, resp.Events)
+	assert.Equal(t, uint64(3), resp.LastEventID)
+
+	// No events are returned once the request times out
+	resp = longPoll(t, server.URL+"/poll?label=a&lastEventId=3")
+	assert.Empty(t, resp.Events)
+	assert.Equal(t, uint64(3), resp.LastEventID)
+}
+
+func TestLongPollingHandlerIdleTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	e := echo.New()
+	e.GET("/poll", LongPollingHandler(m, WithLongPollingTimeout(10*time.Millisecond), WithIdleTimeout(100*time.Millisecond)))
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	longPoll(t, server.URL+"/poll")
+	_, ok := m.GetBroadcaster("/poll")
+	assert.True(t, ok, "the topic should be kept within the idle timeout")
+	require.Eventually(t, func() bool {
+		_, ok := m.GetBroadcaster("/poll")
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestLongPollingHandlerIdleTimeoutOfExistingTopic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	e := echo.New()
+	e.GET("/poll", LongPollingHandler(m, WithLongPollingTimeout(10*time.Millisecond), WithIdleTimeout(100*time.Millisecond)))
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	// The topic is created by another handler without an idle timeout
+	b, _ := m.CreateOrGetBroadcaster("/poll")
+	ch := b.Subscribe()
+	longPoll(t, server.URL+"/poll")
+	b.Unsubscribe(ch)
+
+	_, ok := m.GetBroadcaster("/poll")
+	assert.True(t, ok, "the topic should be kept within the idle timeout of the long-polling client")
+	require.Eventually(t, func() bool {
+		_, ok := m.GetBroadcaster("/poll")
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestLongPollingHandlerInvalidLastEventID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/poll?lastEventId=abc", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, LongPollingHandler(m)(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestConstructLongPollingTopic(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"no query", "/api/v3/device/all", "/api/v3/device/all"},
+		{"only last event ID", "/api/v3/device/all?lastEventId=3", "/api/v3/device/all"},
+		{"last event ID excluded", "/api/v3/device/all?offset=10&lastEventId=3&labels=a,b", "/api/v3/device/all?offset=10&labels=a,b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, tt.url, nil), httptest.NewRecorder())
+			assert.Equal(t, tt.expected, constructLongPollingTopic(c))
+		})
+	}
+}
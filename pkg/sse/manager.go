@@ -1,17 +1,33 @@
 //
-// Copyright (C) 2025 IOTech Ltd
+// Copyright (C) 2025-2026 IOTech Ltd
 //
 
 package sse
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
 )
 
+// logComponent is the name of the component logger used by the SSE package
+const logComponent = "sse"
+
+// DefaultEventRetention is the default time the event IDs and history of a topic are retained after its broadcaster
+// is removed
+const DefaultEventRetention = 5 * time.Minute
+
+// retainedEvents are the events of a topic retained after its broadcaster is removed until they expire
+type retainedEvents struct {
+	state   eventState
+	expires time.Time
+}
+
 // Manager manages multiple broadcasters for different topics.
 type Manager struct {
 	// broadcasters hold a map of topic names to their corresponding broadcasters.
@@ -20,20 +36,66 @@ type Manager struct {
 	lc                log.Logger
 	heartbeatInterval time.Duration
 
+	// id identifies the Manager in the messages relayed through the bus
+	id  string
+	bus Bus
+	// relayLc is a sampled logger for the repetitive failures of relaying the publications
+	relayLc log.Logger
+
+	// retained hold the events of the topics whose broadcasters have been removed, so that the clients reconnecting
+	// after the last client of a topic leaves can still replay the missed events with the same event IDs
+	retained       map[string]retainedEvents
+	eventRetention time.Duration
+
+	// connections hold the number of the concurrent connections of each user
+	connections map[string]int
+	connMu      sync.Mutex
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
+// ManagerOption is a function that modifies the Manager.
+type ManagerOption func(*Manager)
+
+// WithBus returns a ManagerOption that relays the publications of the topics across the instances of a service
+// through the Bus, so that the subscribers on all the instances receive them. The data relayed from the other
+// instances is published to the local subscribers as json.RawMessage.
+func WithBus(bus Bus) ManagerOption {
+	return func(m *Manager) {
+		m.bus = bus
+	}
+}
+
+// WithEventRetention returns a ManagerOption that sets how long the event IDs and history of a topic are retained
+// after its broadcaster is removed, i.e. after the last client of the topic leaves and the idle timeout elapses, so
+// that a client reconnecting within the retention continues the same event IDs and has the missed events replayed.
+// A client reconnecting after the retention is handled as a new client. A retention of zero or less disables it.
+// Default is DefaultEventRetention if not set.
+func WithEventRetention(retention time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.eventRetention = retention
+	}
+}
+
 // NewManager creates a new SSE Manager instance.
-func NewManager(ctx context.Context, lc log.Logger, heartbeatInterval time.Duration) *Manager {
+func NewManager(ctx context.Context, lc log.Logger, heartbeatInterval time.Duration, opts ...ManagerOption) *Manager {
 	ctx, cancel := context.WithCancel(ctx)
 
 	manager := &Manager{
 		broadcasters:      make(map[string]*Broadcaster),
-		lc:                lc,
+		lc:                log.Component(lc, logComponent),
 		ctx:               ctx,
 		cancel:            cancel,
 		heartbeatInterval: heartbeatInterval,
+		id:                uuid.NewString(),
+		connections:       make(map[string]int),
+		retained:          make(map[string]retainedEvents),
+		eventRetention:    DefaultEventRetention,
+	}
+	manager.relayLc = log.Sampled(manager.lc, dropLogSampling)
+	for _, opt := range opts {
+		opt(manager)
 	}
 
 	// Gracefully shutdown the SSE manager when the main context is done
@@ -60,6 +122,7 @@ func (m *Manager) GetBroadcaster(topic string) (b *Broadcaster, ok bool) {
 }
 
 // CreateOrGetBroadcaster retrieves a broadcaster for the specified topic or creates a new one if it doesn't exist.
+// A new broadcaster continues the events retained from the previous broadcaster of the topic, if any.
 func (m *Manager) CreateOrGetBroadcaster(topic string) (b *Broadcaster, isNew bool) {
 	if b, ok := m.GetBroadcaster(topic); ok {
 		return b, false
@@ -68,15 +131,85 @@ func (m *Manager) CreateOrGetBroadcaster(topic string) (b *Broadcaster, isNew bo
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// The broadcaster may have been created by another goroutine since the lookup
+	if b, ok := m.broadcasters[topic]; ok {
+		return b, false
+	}
+
 	m.lc.Debugf("sse: Creating new broadcaster for topic '%s'", topic)
 	b = NewBroadcaster(m.lc)
+	if retained, ok := m.retained[topic]; ok {
+		delete(m.retained, topic)
+		if time.Now().Before(retained.expires) {
+			m.lc.Debugf("sse: Restoring the retained events of topic '%s'", topic)
+			b.restore(retained.state)
+		}
+	}
+	disconnectBus := m.connectBus(topic, b)
 	b.SetOnEmptyCallback(func() {
-		m.RemoveBroadcaster(topic)
+		disconnectBus()
+		m.releaseBroadcaster(topic, b)
 	})
 	m.broadcasters[topic] = b
 	return b, true
 }
 
+// releaseBroadcaster removes the broadcaster of the topic unless it has been replaced by another one, and retains its
+// events for the event retention. The expired events of the other topics are dropped meanwhile.
+func (m *Manager) releaseBroadcaster(topic string, b *Broadcaster) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.broadcasters[topic] != b {
+		return
+	}
+	delete(m.broadcasters, topic)
+	m.lc.Debugf("sse: Broadcaster of topic '%s' has been removed", topic)
+
+	if m.eventRetention <= 0 {
+		return
+	}
+	now := time.Now()
+	for t, retained := range m.retained {
+		if now.After(retained.expires) {
+			delete(m.retained, t)
+		}
+	}
+	if state := b.snapshot(); state.lastEvent != nil {
+		m.retained[topic] = retainedEvents{state: state, expires: now.Add(m.eventRetention)}
+	}
+}
+
+// connectBus relays the changed publications of the broadcaster through the bus, and publishes the messages of the
+// topic relayed from the other instances to the broadcaster. It returns a function to disconnect the broadcaster.
+func (m *Manager) connectBus(topic string, b *Broadcaster) func() {
+	if m.bus == nil {
+		return func() {}
+	}
+
+	b.relay = func(eventType string, data any) {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			m.relayLc.Errorf("sse: Failed to marshal the publication of topic '%s' for relaying: %v", topic, err)
+			return
+		}
+		if err := m.bus.Publish(BusMessage{Origin: m.id, Topic: topic, Type: eventType, Data: payload}); err != nil {
+			m.relayLc.Warnf("sse: Failed to relay the publication of topic '%s': %v", topic, err)
+		}
+	}
+	cancel, err := m.bus.Subscribe(topic, func(msg BusMessage) {
+		if msg.Origin == m.id {
+			return
+		}
+		b.publishEvent(msg.Type, msg.Data)
+	})
+	if err != nil {
+		m.lc.Errorf("sse: Failed to subscribe topic '%s' from the bus: %v", topic, err)
+		return func() {}
+	}
+	return cancel
+}
+
 // RemoveBroadcaster removes a broadcaster for the specified topic.
 func (m *Manager) RemoveBroadcaster(topic string) {
 	m.mu.Lock()
@@ -4,14 +4,72 @@
 
 package sse
 
-import "time"
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
 
 // HandlerConfig holds the configuration for the SSE handler.
 type HandlerConfig struct {
 	PollingService PollingService
-	CustomTopic    string
+	// PollingServiceFactory creates the PollingService of a new topic, which takes precedence over PollingService.
+	PollingServiceFactory func(topic string) PollingService
+	CustomTopic           string
+	// EventHistorySize is the number of the recent events kept for replaying to the reconnecting clients.
+	// Zero means DefaultEventHistorySize, and a negative value disables the replay.
+	EventHistorySize int
+	// RetryInterval is the reconnection time sent to the clients on connect, zero to leave it to the clients.
+	RetryInterval time.Duration
+	// SubscriberBufferSize is the size of the channel buffering the messages of each subscriber.
+	// Default is DefaultSubscriberBufferSize if not set.
+	SubscriberBufferSize int
+	// OverflowPolicy defines how a subscriber is handled if its channel is full. Default is OverflowDropNewest.
+	OverflowPolicy OverflowPolicy
+	// DeltaMode defines how the updates are sent after the initial full snapshot. Default is DeltaNone.
+	DeltaMode DeltaMode
+	// IdleTimeout is how long a topic keeps polling after its last client leaves. Default is zero for Handler and
+	// WebSocketHandler, and defaultLongPollingIdleTimeout for LongPollingHandler.
+	IdleTimeout time.Duration
+	// LongPollingTimeout is how long a request of LongPollingHandler waits for a new event.
+	// Default is defaultLongPollingTimeout if not set.
+	LongPollingTimeout time.Duration
+	// SubscriberTransform derives the TransformFunc of a subscriber from its request, nil to deliver the data as is.
+	SubscriberTransform func(c echo.Context) TransformFunc
+	// Authorizer authorizes the subscriptions of the clients, nil to accept all the subscriptions.
+	Authorizer AuthorizeFunc
+	// ClaimsFunc returns the verified claims of the JWT of a request, nil to use the claims verified by the
+	// authentication middleware.
+	ClaimsFunc ClaimsFunc
+	// MaxConnectionsPerUser is the maximum number of the concurrent connections of a user, zero for unlimited.
+	MaxConnectionsPerUser int
+	// Compression enables compressing the SSE streams with the content encoding negotiated with the clients.
+	Compression bool
+	// MaxEventSize is the maximum size of the data of an event in bytes, zero for unlimited.
+	MaxEventSize int
+	// OversizedEventPolicy defines how the events exceeding MaxEventSize are handled. Default is OversizedEventReject.
+	OversizedEventPolicy OversizedEventPolicy
+	// AllowedOrigins are the origins of the cross-origin WebSocket connections accepted by WebSocketHandler, in
+	// addition to the host of the request. AllowAllOrigins accepts all the origins.
+	AllowedOrigins []string
+	// MaxSubscriptionsPerConnection is the maximum number of the topics subscribed over a WebSocket connection.
+	// Default is DefaultMaxWebSocketSubscriptions if not set.
+	MaxSubscriptionsPerConnection int
 }
 
+// AuthorizeFunc authorizes the subscription of a client to the topic resolved from its request, given the verified
+// claims of the JWT of the request, i.e. the claims stored in the echo context by the authentication middleware or
+// those returned by WithClaimsFunc. The claims are empty if the request isn't authenticated, in which case the JWT of
+// the request, if any, must not be trusted. It returns the topic to subscribe, which is either the resolved topic or a
+// narrowed one, e.g. the topic filtered to the devices of the user, or an error to reject the subscription with 403
+// Forbidden.
+type AuthorizeFunc func(c echo.Context, topic string, claims jwt.MapClaims) (string, error)
+
+// ClaimsFunc returns the claims of the JWT of a request once it is verified, or false if the request doesn't carry a
+// valid JWT.
+type ClaimsFunc func(c echo.Context) (jwt.MapClaims, bool)
+
 // HandlerOption is a function that modifies the HandlerConfig.
 type HandlerOption func(*HandlerConfig)
 
@@ -22,6 +80,15 @@ func WithPollingService(service PollingService) HandlerOption {
 	}
 }
 
+// WithPollingServiceFactory returns a HandlerOption that sets a function creating the PollingService of each new
+// topic, or nil if the topic doesn't need polling. It is required to poll the topics subscribed by the clients of
+// WebSocketHandler, which can subscribe multiple topics. It takes precedence over WithPollingService.
+func WithPollingServiceFactory(factory func(topic string) PollingService) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.PollingServiceFactory = factory
+	}
+}
+
 // WithCustomTopic returns a HandlerOption that sets a custom topic in the HandlerConfig.
 func WithCustomTopic(topic string) HandlerOption {
 	return func(config *HandlerConfig) {
@@ -29,11 +96,222 @@ func WithCustomTopic(topic string) HandlerOption {
 	}
 }
 
+// WithEventHistorySize returns a HandlerOption that sets the number of the recent events kept for replaying to the
+// clients reconnecting with the Last-Event-ID header. A negative size disables the replay.
+// Default is DefaultEventHistorySize if not set.
+func WithEventHistorySize(size int) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.EventHistorySize = size
+	}
+}
+
+// WithRetryInterval returns a HandlerOption that sets the reconnection time sent to the clients as the SSE retry
+// field on connect, which the clients wait for before reconnecting once the connection is lost.
+func WithRetryInterval(interval time.Duration) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.RetryInterval = interval
+	}
+}
+
+// WithSubscriberTransform returns a HandlerOption that sets a function deriving a TransformFunc from the request of
+// each subscriber, e.g. from its query parameters, so that the subscribers sharing a broadcaster receive their own
+// projections of the published data. It is usually used along with WithCustomTopic, so that the requests with
+// different query parameters share a broadcaster and its polling rather than creating one per query string.
+func WithSubscriberTransform(fn func(c echo.Context) TransformFunc) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.SubscriberTransform = fn
+	}
+}
+
+// WithSubscriberBufferSize returns a HandlerOption that sets the size of the channel buffering the messages of each
+// subscriber. Default is DefaultSubscriberBufferSize if not set.
+func WithSubscriberBufferSize(size int) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.SubscriberBufferSize = size
+	}
+}
+
+// WithOverflowPolicy returns a HandlerOption that sets how a subscriber is handled if it is too slow to receive the
+// published messages, e.g. OverflowLatestOnly for dashboards and OverflowDisconnect for event feeds.
+// Default is OverflowDropNewest if not set.
+func WithOverflowPolicy(policy OverflowPolicy) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.OverflowPolicy = policy
+	}
+}
+
+// WithDeltaMode returns a HandlerOption that sets how the updates of the default message type are sent to each client
+// after the initial full snapshot, e.g. DeltaJSONPatch to send the changes against the previous payload as JSON Patch
+// documents under the EventTypeJSONPatch event type. An update is still sent as a full snapshot if its delta isn't
+// smaller. Default is DeltaNone, which sends every update as a full snapshot.
+func WithDeltaMode(mode DeltaMode) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.DeltaMode = mode
+	}
+}
+
+// WithIdleTimeout returns a HandlerOption that sets how long a new topic keeps polling and its event history after the
+// last client leaves, so that the clients reconnecting within the timeout share the same polling and event history.
+// The event IDs and history are still retained by the Manager after the timeout, see WithEventRetention.
+func WithIdleTimeout(timeout time.Duration) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.IdleTimeout = timeout
+	}
+}
+
+// WithLongPollingTimeout returns a HandlerOption that sets how long a request of LongPollingHandler waits for a new
+// event before responding with no events. Default is 30 seconds if not set.
+func WithLongPollingTimeout(timeout time.Duration) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.LongPollingTimeout = timeout
+	}
+}
+
+// WithAuthorizer returns a HandlerOption that sets the function authorizing the subscription of each client to the
+// resolved topic, which can reject the subscription or narrow it to another topic. The topic subscribed by each
+// request of WebSocketHandler is authorized separately, and a rejected request is answered with an error event.
+func WithAuthorizer(fn AuthorizeFunc) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.Authorizer = fn
+	}
+}
+
+// WithClaimsFunc returns a HandlerOption that sets the function returning the verified claims of the JWT of a request,
+// which are passed to the Authorizer and identify the user for WithMaxConnectionsPerUser, e.g. to verify the access
+// token cookie sent by the browser EventSource, which can't set the Authorization header. Default is
+// jwt.VerifiedClaimsFromContext, i.e. the claims verified by the authentication middleware of the route.
+func WithClaimsFunc(fn ClaimsFunc) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.ClaimsFunc = fn
+	}
+}
+
+// WithMaxConnectionsPerUser returns a HandlerOption that caps the concurrent connections of each user across all the
+// handlers of the Manager, and the connections exceeding the cap are rejected with 429 Too Many Requests. The user is
+// identified by the verified claims of the JWT of the request, see WithClaimsFunc, or by the client IP address if the
+// request isn't authenticated. Default is zero, which means unlimited.
+func WithMaxConnectionsPerUser(max int) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.MaxConnectionsPerUser = max
+	}
+}
+
+// WithCompression returns a HandlerOption that compresses the SSE streams with gzip or deflate, whichever is accepted
+// by the client with the Accept-Encoding header, preferring gzip. The stream is flushed after each event, so that the
+// events still arrive promptly. The streams of the clients accepting neither of them are sent uncompressed.
+func WithCompression() HandlerOption {
+	return func(config *HandlerConfig) {
+		config.Compression = true
+	}
+}
+
+// WithMaxEventSize returns a HandlerOption that sets the maximum size of the serialized data of an event in bytes,
+// and how the events exceeding it are handled, so that a slow client isn't sent megabytes of data. The size is
+// checked against the data written to the client, i.e. the delta update if WithDeltaMode is set.
+// Default is zero, which means unlimited.
+func WithMaxEventSize(size int, policy OversizedEventPolicy) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.MaxEventSize = size
+		config.OversizedEventPolicy = policy
+	}
+}
+
+// WithAllowedOrigins returns a HandlerOption that sets the origins, e.g. "https://console.example.com", of the
+// cross-origin WebSocket connections accepted by WebSocketHandler, such as the origin of a UI served by another host
+// or the public origin of the service behind a reverse proxy rewriting the Host header. AllowAllOrigins disables the
+// origin check, which should only be used if the route doesn't authenticate the clients with the cookies.
+func WithAllowedOrigins(origins ...string) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.AllowedOrigins = origins
+	}
+}
+
+// WithMaxSubscriptionsPerConnection returns a HandlerOption that caps the number of the topics subscribed over a
+// WebSocket connection, and the subscribe requests exceeding the cap are answered with a 429 Too Many Requests error
+// event. Default is DefaultMaxWebSocketSubscriptions if not set.
+func WithMaxSubscriptionsPerConnection(max int) HandlerOption {
+	return func(config *HandlerConfig) {
+		config.MaxSubscriptionsPerConnection = max
+	}
+}
+
+// SubscribeConfig holds the configuration of a subscription to a Broadcaster.
+type SubscribeConfig struct {
+	// LastEventID is the ID of the last event received by a reconnecting subscriber, zero if none.
+	LastEventID uint64
+	// Transform is the projection of the published data for the subscriber, nil to deliver the data as is.
+	Transform TransformFunc
+	// BufferSize is the size of the channel of the subscriber. Default is DefaultSubscriberBufferSize if not set.
+	BufferSize int
+	// OverflowPolicy defines how the subscriber is handled if its channel is full. Default is OverflowDropNewest.
+	OverflowPolicy OverflowPolicy
+	// LatestEvent indicates whether the latest event is sent to the new subscriber immediately on subscription.
+	LatestEvent bool
+	// Events indicates whether the messages are delivered as Event, carrying the ID and type of the events, rather
+	// than the published data.
+	Events bool
+}
+
+// SubscribeOption is a function that modifies the SubscribeConfig.
+type SubscribeOption func(*SubscribeConfig)
+
+// WithLastEventID returns a SubscribeOption that sets the ID of the last event received by a reconnecting subscriber,
+// so that the buffered events after it are replayed to the subscriber.
+func WithLastEventID(id uint64) SubscribeOption {
+	return func(config *SubscribeConfig) {
+		config.LastEventID = id
+	}
+}
+
+// WithTransform returns a SubscribeOption that sets the projection of the published data for the subscriber. The
+// projections are deduplicated per subscriber, so the subscriber only receives an update if its projection changes.
+func WithTransform(fn TransformFunc) SubscribeOption {
+	return func(config *SubscribeConfig) {
+		config.Transform = fn
+	}
+}
+
+// WithSubscriberBuffer returns a SubscribeOption that sets the size of the channel of the subscriber and how the
+// subscriber is handled if the channel is full. The size is ignored with OverflowLatestOnly, which buffers only the
+// latest message, and is raised to hold all the replayed events of a reconnecting subscriber.
+func WithSubscriberBuffer(size int, policy OverflowPolicy) SubscribeOption {
+	return func(config *SubscribeConfig) {
+		config.BufferSize = size
+		config.OverflowPolicy = policy
+	}
+}
+
+// WithLatestEvent returns a SubscribeOption that sends the latest event to the new subscriber immediately on
+// subscription rather than on the next publish, unless the subscriber is up-to-date after replaying the events.
+func WithLatestEvent() SubscribeOption {
+	return func(config *SubscribeConfig) {
+		config.LatestEvent = true
+	}
+}
+
+// WithEvents returns a SubscribeOption that delivers the messages to the channel of the subscriber as Event, so that
+// the subscriber receives the ID and type of each event along with its data.
+func WithEvents() SubscribeOption {
+	return func(config *SubscribeConfig) {
+		config.Events = true
+	}
+}
+
 type PollingConfig struct {
 	interval      time.Duration
 	ApiVersion    string
 	StopCondition func(data any) bool
 	StopCallback  func()
+	// ErrorBackoff enables the exponential backoff of the interval on the consecutive polling errors, and
+	// BackoffJitter is the fraction of the interval randomized to spread the polling of the instances.
+	ErrorBackoff  bool
+	BackoffJitter float64
+	// AdaptiveThreshold is the number of the consecutive polls with unchanged data before the interval is lengthened,
+	// zero to disable the adaptive interval.
+	AdaptiveThreshold int
+	// MaxInterval caps the interval lengthened by the backoff and the adaptive interval, which is raised to the
+	// polling interval if shorter. Default is 12 times the polling interval if not set.
+	MaxInterval time.Duration
 }
 
 // PollingOption is a function that modifies the PollingConfig.
@@ -71,3 +349,31 @@ func WithStopCallback(fn func()) PollingOption {
 		config.StopCallback = fn
 	}
 }
+
+// WithErrorBackoff returns a PollingOption that doubles the polling interval on each consecutive error of the polling
+// function, up to the max interval, and snaps back to the polling interval once the polling succeeds. The interval is
+// randomized by the jitter fraction, e.g. 0.2 for ±20%, so that the instances don't retry the data source in lockstep.
+func WithErrorBackoff(jitter float64) PollingOption {
+	return func(config *PollingConfig) {
+		config.ErrorBackoff = true
+		config.BackoffJitter = jitter
+	}
+}
+
+// WithAdaptiveInterval returns a PollingOption that doubles the polling interval each time the data hasn't changed for
+// the given number of consecutive polls, up to the max interval, and snaps back to the polling interval once the data
+// changes.
+func WithAdaptiveInterval(unchangedPolls int) PollingOption {
+	return func(config *PollingConfig) {
+		config.AdaptiveThreshold = unchangedPolls
+	}
+}
+
+// WithMaxPollingInterval returns a PollingOption that caps the polling interval lengthened by WithErrorBackoff and
+// WithAdaptiveInterval, including the jitter of the backoff. A max interval shorter than the polling interval is
+// raised to the polling interval. Default is 12 times the polling interval if not set.
+func WithMaxPollingInterval(interval time.Duration) PollingOption {
+	return func(config *PollingConfig) {
+		config.MaxInterval = interval
+	}
+}
@@ -7,6 +7,7 @@ package sse
 import (
 	"context"
 	goErr "errors"
+	"math/rand/v2"
 	"net/http"
 	"sync"
 	"time"
@@ -17,6 +18,9 @@ import (
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
 )
 
+// defaultMaxIntervalFactor is the default max interval lengthened by the backoff, in multiples of the polling interval
+const defaultMaxIntervalFactor = 12
+
 // Polling is a struct that implements a polling mechanism for fetching data from a data source at regular intervals.
 // It is designed to be started once and can be stopped gracefully.
 type Polling struct {
@@ -27,6 +31,16 @@ type Polling struct {
 	stopCallback  func()
 	lc            log.Logger
 
+	errorBackoff      bool
+	backoffJitter     float64
+	adaptiveThreshold int
+	maxInterval       time.Duration
+	// consecutiveErrors, unchangedPolls and lastHash are the state of the polling goroutine for lengthening the
+	// interval
+	consecutiveErrors int
+	unchangedPolls    int
+	lastHash          string
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
@@ -48,13 +62,24 @@ func NewPolling(lc log.Logger, pollingFunc func(context.Context) (any, error), o
 		config.interval = 5 * time.Second
 	}
 
+	if config.MaxInterval <= 0 {
+		config.MaxInterval = defaultMaxIntervalFactor * config.interval
+	}
+	// The max interval never shortens the polling interval
+	config.MaxInterval = max(config.MaxInterval, config.interval)
+
 	return &Polling{
 		apiVersion:    config.ApiVersion,
 		interval:      config.interval,
 		pollingFunc:   pollingFunc,
-		lc:            lc,
+		lc:            log.Component(lc, logComponent),
 		stopCondition: config.StopCondition,
 		stopCallback:  config.StopCallback,
+
+		errorBackoff:      config.ErrorBackoff,
+		backoffJitter:     min(max(config.BackoffJitter, 0), 1),
+		adaptiveThreshold: config.AdaptiveThreshold,
+		maxInterval:       config.MaxInterval,
 	}
 }
 
@@ -91,9 +116,15 @@ func (p *Polling) pollingAndPublish(publisher Publisher) {
 		data, err := p.pollingFunc(p.ctx)
 		if err != nil {
 			p.lc.Errorf("sse polling: Failed to fetch data: %v", err)
-			publisher.Publish(p.getErrorResponse(err))
+			p.consecutiveErrors++
+			// The data is considered changed once the polling recovers
+			p.unchangedPolls = 0
+			p.lastHash = ""
+			publishError(publisher, p.getErrorResponse(err))
 			return
 		}
+		p.consecutiveErrors = 0
+		p.trackChanges(data)
 		publisher.Publish(data)
 		if p.stopCondition != nil && p.stopCondition(data) {
 			p.lc.Debug("sse polling: Stop condition met, stopping polling")
@@ -106,13 +137,14 @@ func (p *Polling) pollingAndPublish(publisher Publisher) {
 	// Initial poll to fetch data immediately before starting the ticker
 	doPollAndPublish()
 
-	ticker := time.NewTicker(p.interval)
-	defer ticker.Stop()
+	timer := time.NewTimer(p.nextInterval())
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			doPollAndPublish()
+			timer.Reset(p.nextInterval())
 		case <-p.ctx.Done():
 			p.lc.Debug("sse polling: Polling context cancelled")
 			return
@@ -120,6 +152,55 @@ func (p *Polling) pollingAndPublish(publisher Publisher) {
 	}
 }
 
+// publishError publishes the error response under the error event type if the publisher supports event types
+func publishError(publisher Publisher, resp rest.BaseResponse) {
+	if eventPublisher, ok := publisher.(EventPublisher); ok {
+		eventPublisher.PublishEvent(EventTypeError, resp)
+		return
+	}
+	publisher.Publish(resp)
+}
+
+// trackChanges counts the consecutive polls with unchanged data for the adaptive interval
+func (p *Polling) trackChanges(data any) {
+	if p.adaptiveThreshold <= 0 {
+		return
+	}
+	hash, err := hashOf(data)
+	if err != nil || hash != p.lastHash {
+		p.unchangedPolls = 0
+		p.lastHash = hash
+		return
+	}
+	p.unchangedPolls++
+}
+
+// nextInterval returns the interval before the next poll, which is doubled on each consecutive error with the error
+// backoff, or each time the data hasn't changed for the threshold number of polls with the adaptive interval.
+func (p *Polling) nextInterval() time.Duration {
+	var doublings int
+	switch {
+	case p.errorBackoff && p.consecutiveErrors > 0:
+		doublings = p.consecutiveErrors
+	case p.adaptiveThreshold > 0 && p.consecutiveErrors == 0:
+		doublings = p.unchangedPolls / p.adaptiveThreshold
+	}
+	if doublings == 0 {
+		return p.interval
+	}
+
+	interval := p.interval
+	for i := 0; i < doublings && interval < p.maxInterval; i++ {
+		interval *= 2
+	}
+	if p.consecutiveErrors > 0 && p.backoffJitter > 0 {
+		interval = time.Duration(float64(interval) * (1 + p.backoffJitter*(2*rand.Float64()-1)))
+	}
+	interval = min(interval, p.maxInterval)
+	p.lc.Debugf("sse polling: Next poll in %v after %d consecutive errors and %d unchanged polls", interval, p.consecutiveErrors, p.unchangedPolls)
+	return interval
+}
+
 func (p *Polling) getErrorResponse(err error) rest.BaseResponse {
 	var (
 		e    errors.Error
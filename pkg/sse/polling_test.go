@@ -9,10 +9,12 @@ import (
 	"errors"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	iotechErrors "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/errors"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
 	loggerMocks "github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log/mocks"
 	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
 	"github.com/stretchr/testify/mock"
@@ -284,3 +286,133 @@ func TestPolling_StopCallback_CalledOnStopCondition(t *testing.T) {
 	}
 	require.NoError(t, p.Stop())
 }
+
+// mockEventPublisher records published values along with their event types.
+type mockEventPublisher struct {
+	mockPublisher
+	eventTypes []string
+}
+
+func (p *mockEventPublisher) Publish(data any) {
+	p.PublishEvent("", data)
+}
+
+func (p *mockEventPublisher) PublishEvent(eventType string, data any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.values = append(p.values, data)
+	p.eventTypes = append(p.eventTypes, eventType)
+}
+
+// TestPolling_PublishesErrorEvent verifies that an error response is published under the error event type
+// if the publisher supports event types.
+func TestPolling_PublishesErrorEvent(t *testing.T) {
+	lc := newTestLogger(t)
+	pub := &mockEventPublisher{}
+	var failed atomic.Bool
+
+	p := NewPolling(lc,
+		func(_ context.Context) (any, error) {
+			if failed.CompareAndSwap(false, true) {
+				return nil, errors.New("something broke")
+			}
+			return "data", nil
+		},
+		WithCustomPollingInterval(10*time.Millisecond),
+	)
+
+	p.Start(pub)
+	require.Eventually(t, func() bool {
+		return len(pub.published()) >= 2
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Stop())
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	assert.Equal(t, []string{EventTypeError, ""}, pub.eventTypes[:2])
+	assert.IsType(t, rest.BaseResponse{}, pub.values[0])
+	assert.Equal(t, "data", pub.values[1])
+}
+
+// TestPolling_NextInterval verifies the interval lengthened by the error backoff and the adaptive interval.
+func TestPolling_NextInterval(t *testing.T) {
+	newPolling := func(opts ...PollingOption) *Polling {
+		opts = append([]PollingOption{WithCustomPollingInterval(time.Second)}, opts...)
+		return NewPolling(log.NewNopeLogger(), func(_ context.Context) (any, error) { return nil, nil }, opts...)
+	}
+
+	t.Run("fixed interval by default", func(t *testing.T) {
+		p := newPolling()
+		p.consecutiveErrors = 3
+		assert.Equal(t, time.Second, p.nextInterval())
+	})
+
+	t.Run("error backoff", func(t *testing.T) {
+		p := newPolling(WithErrorBackoff(0), WithMaxPollingInterval(5*time.Second))
+		for errors, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+			p.consecutiveErrors = errors
+			assert.Equal(t, expected, p.nextInterval(), "after %d errors", errors)
+		}
+		p.consecutiveErrors = 1000
+		assert.Equal(t, 5*time.Second, p.nextInterval())
+	})
+
+	t.Run("error backoff with jitter", func(t *testing.T) {
+		p := newPolling(WithErrorBackoff(0.5))
+		p.consecutiveErrors = 2
+		for i := 0; i < 100; i++ {
+			interval := p.nextInterval()
+			assert.GreaterOrEqual(t, interval, 2*time.Second)
+			assert.LessOrEqual(t, interval, 6*time.Second)
+		}
+	})
+
+	t.Run("jitter capped by the max interval", func(t *testing.T) {
+		p := newPolling(WithErrorBackoff(0.5), WithMaxPollingInterval(4*time.Second))
+		p.consecutiveErrors = 5
+		for i := 0; i < 100; i++ {
+			interval := p.nextInterval()
+			assert.GreaterOrEqual(t, interval, 2*time.Second)
+			assert.LessOrEqual(t, interval, 4*time.Second)
+		}
+	})
+
+	t.Run("max interval shorter than the interval", func(t *testing.T) {
+		p := newPolling(WithErrorBackoff(0), WithMaxPollingInterval(time.Millisecond))
+		assert.Equal(t, time.Second, p.maxInterval, "raised to the polling interval")
+		p.consecutiveErrors = 3
+		assert.Equal(t, time.Second, p.nextInterval())
+	})
+
+	t.Run("adaptive interval", func(t *testing.T) {
+		p := newPolling(WithAdaptiveInterval(3))
+		assert.Equal(t, 12*time.Second, p.maxInterval, "the default max interval")
+		p.trackChanges("a")
+		for i := 0; i < 6; i++ {
+			p.trackChanges("a")
+		}
+		assert.Equal(t, 4*time.Second, p.nextInterval(), "doubled twice after 6 unchanged polls")
+		p.trackChanges("b")
+		assert.Equal(t, time.Second, p.nextInterval(), "snapped back once the data changes")
+	})
+}
+
+// TestPolling_ErrorBackoff verifies that the data source isn't polled at the fixed interval while it keeps failing.
+func TestPolling_ErrorBackoff(t *testing.T) {
+	var polls atomic.Int32
+	p := NewPolling(log.NewNopeLogger(),
+		func(_ context.Context) (any, error) {
+			polls.Add(1)
+			return nil, errors.New("something broke")
+		},
+		WithCustomPollingInterval(20*time.Millisecond),
+		WithErrorBackoff(0),
+	)
+
+	p.Start(&mockPublisher{})
+	time.Sleep(250 * time.Millisecond)
+	require.NoError(t, p.Stop())
+
+	// Polled at 0, 40, 120 and 280ms with the backoff, rather than 12 times at the fixed interval
+	assert.LessOrEqual(t, polls.Load(), int32(4))
+}
@@ -0,0 +1,345 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	goErr "errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+// Networks supported by SocketBus
+const (
+	SocketBusNetworkTCP  = "tcp"
+	SocketBusNetworkUnix = "unix"
+)
+
+// SocketBusConfig defines the socket shared by the processes of a SocketBus.
+type SocketBusConfig struct {
+	// Network is either SocketBusNetworkTCP or SocketBusNetworkUnix
+	Network string
+	// Address is the TCP address, e.g. "127.0.0.1:59999", or the path of the Unix socket
+	Address string
+	// ReconnectInterval is the interval of retrying to become the hub or connect to it. It defaults to 1s.
+	ReconnectInterval time.Duration
+	// WriteTimeout is the timeout of writing a message to a process. It defaults to 5s.
+	WriteTimeout time.Duration
+	// SendQueueSize is the number of the messages queued for sending to each process, and a process whose queue is
+	// full is disconnected. It defaults to 1024.
+	SendQueueSize int
+}
+
+var defaultSocketBusConfig = SocketBusConfig{
+	ReconnectInterval: time.Second,
+	WriteTimeout:      5 * time.Second,
+	SendQueueSize:     1024,
+}
+
+func (c *SocketBusConfig) setDefault() {
+	if c.ReconnectInterval <= 0 {
+		c.ReconnectInterval = defaultSocketBusConfig.ReconnectInterval
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = defaultSocketBusConfig.WriteTimeout
+	}
+	if c.SendQueueSize <= 0 {
+		c.SendQueueSize = defaultSocketBusConfig.SendQueueSize
+	}
+}
+
+// SocketBus is a Bus over a TCP or Unix socket for the processes on the same host. The first process listening on
+// the address becomes the hub, which relays the messages between the other processes connecting to it. Once the hub
+// exits, the other processes race to become the new hub. The messages are exchanged as JSON lines, and the messages
+// published while a process is disconnected are only delivered locally. The messages are queued for each process and
+// written by a goroutine of the process, so that a stuck process doesn't block the publishers.
+type SocketBus struct {
+	config   SocketBusConfig
+	lc       log.Logger
+	handlers *busHandlers
+
+	mu sync.Mutex
+	// peers are the connected processes, which are the other processes for the hub or the hub otherwise
+	peers map[*socketPeer]struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type socketPeer struct {
+	conn  net.Conn
+	queue chan BusMessage
+	// done is closed once the peer is disconnected
+	done chan struct{}
+}
+
+// enqueue queues the message for sending to the peer without blocking, and returns false if the queue is full
+func (p *socketPeer) enqueue(msg BusMessage) bool {
+	select {
+	case p.queue <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeMessages writes the queued messages to the peer until it is disconnected. The connection is closed once a
+// write fails, so that the peer is disconnected and reconnects by itself.
+func (p *socketPeer) writeMessages(lc log.Logger, timeout time.Duration) {
+	encoder := json.NewEncoder(p.conn)
+	for {
+		select {
+		case msg := <-p.queue:
+			err := p.conn.SetWriteDeadline(time.Now().Add(timeout))
+			if err == nil {
+				err = encoder.Encode(msg)
+			}
+			if err != nil {
+				lc.Debugf("sse bus: Failed to write to %s: %v", p.conn.RemoteAddr(), err)
+				_ = p.conn.Close()
+				return
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// NewSocketBus creates a SocketBus, which keeps becoming the hub or connecting to it in the background until the
+// context is done or it is closed.
+func NewSocketBus(ctx context.Context, lc log.Logger, config SocketBusConfig) (*SocketBus, error) {
+	if config.Network != SocketBusNetworkTCP && config.Network != SocketBusNetworkUnix {
+		return nil, fmt.Errorf("unsupported socket bus network '%s'", config.Network)
+	}
+	if config.Address == "" {
+		return nil, fmt.Errorf("socket bus address is required")
+	}
+	config.setDefault()
+
+	ctx, cancel := context.WithCancel(ctx)
+	b := &SocketBus{
+		config:   config,
+		lc:       log.Component(lc, logComponent),
+		handlers: newBusHandlers(),
+		peers:    make(map[*socketPeer]struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.run()
+	}()
+	return b, nil
+}
+
+// Publish delivers the message to the local handlers of its topic, and sends it to the other processes.
+func (b *SocketBus) Publish(msg BusMessage) error {
+	b.handlers.dispatch(msg)
+	return b.broadcast(msg, nil)
+}
+
+// Subscribe registers a handler of the messages of the topic.
+func (b *SocketBus) Subscribe(topic string, handler func(msg BusMessage)) (func(), error) {
+	return b.handlers.add(topic, handler), nil
+}
+
+// Close disconnects the SocketBus from the other processes.
+func (b *SocketBus) Close() error {
+	b.cancel()
+	b.wg.Wait()
+	return nil
+}
+
+func (b *SocketBus) run() {
+	for {
+		b.serveOrConnect()
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-time.After(b.config.ReconnectInterval):
+		}
+	}
+}
+
+// serveOrConnect becomes the hub if the address is available, or connects to the hub otherwise. It returns once the
+// process is no longer the hub or disconnected from the hub.
+func (b *SocketBus) serveOrConnect() {
+	listener, err := net.Listen(b.config.Network, b.config.Address)
+	if err == nil {
+		b.lc.Debugf("sse bus: Serving as the hub at %s", b.config.Address)
+		b.serveHub(listener)
+		return
+	}
+
+	stale, statErr := os.Stat(b.config.Address)
+	conn, dialErr := net.Dial(b.config.Network, b.config.Address)
+	if dialErr != nil {
+		if b.config.Network == SocketBusNetworkUnix && goErr.Is(dialErr, syscall.ECONNREFUSED) && statErr == nil {
+			b.removeStaleSocket(stale)
+			return
+		}
+		b.lc.Debugf("sse bus: Failed to serve or connect to the hub at %s: %v, %v", b.config.Address, err, dialErr)
+		return
+	}
+
+	b.lc.Debugf("sse bus: Connected to the hub at %s", b.config.Address)
+	b.servePeer(b.addPeer(conn), false)
+}
+
+// removeStaleSocket removes the socket file left by a hub which exited abnormally, so that the process can become the
+// new hub. As the other processes may be removing it and becoming the new hub at the same time, the hub is dialed
+// again right before the removal, and the file is only removed if it is still the one refusing the connections.
+func (b *SocketBus) removeStaleSocket(stale os.FileInfo) {
+	conn, err := net.Dial(b.config.Network, b.config.Address)
+	if err == nil {
+		_ = conn.Close()
+		return
+	}
+	current, statErr := os.Stat(b.config.Address)
+	if !goErr.Is(err, syscall.ECONNREFUSED) || statErr != nil || !os.SameFile(stale, current) {
+		return
+	}
+	b.lc.Debugf("sse bus: Removing the stale socket %s", b.config.Address)
+	_ = os.Remove(b.config.Address)
+}
+
+// watchSocket closes the listener of the hub once its socket file is replaced, i.e. removed by another process which
+// mistook it for a stale socket, so that the processes connect to the new hub rather than splitting into two buses.
+func (b *SocketBus) watchSocket(listener net.Listener, socket os.FileInfo, done <-chan struct{}) {
+	ticker := time.NewTicker(b.config.ReconnectInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if current, err := os.Stat(b.config.Address); err == nil && os.SameFile(socket, current) {
+				continue
+			}
+			b.lc.Warnf("sse bus: The socket %s of the hub has been replaced, reconnecting", b.config.Address)
+			_ = listener.Close()
+			return
+		case <-done:
+			return
+		}
+	}
+}
+
+func (b *SocketBus) serveHub(listener net.Listener) {
+	stop := context.AfterFunc(b.ctx, func() {
+		_ = listener.Close()
+	})
+	defer stop()
+	if unixListener, ok := listener.(*net.UnixListener); ok {
+		// The socket file is only removed on close if it still belongs to the hub
+		unixListener.SetUnlinkOnClose(false)
+		if socket, err := os.Stat(b.config.Address); err == nil {
+			done := make(chan struct{})
+			defer func() {
+				close(done)
+				if current, err := os.Stat(b.config.Address); err == nil && os.SameFile(socket, current) {
+					_ = os.Remove(b.config.Address)
+				}
+			}()
+			go b.watchSocket(listener, socket, done)
+		}
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			_ = listener.Close()
+			// Disconnect the processes, so that they reconnect to the new hub
+			b.mu.Lock()
+			for peer := range b.peers {
+				_ = peer.conn.Close()
+			}
+			b.mu.Unlock()
+			return
+		}
+		peer := b.addPeer(conn)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.servePeer(peer, true)
+		}()
+	}
+}
+
+func (b *SocketBus) addPeer(conn net.Conn) *socketPeer {
+	peer := &socketPeer{
+		conn:  conn,
+		queue: make(chan BusMessage, b.config.SendQueueSize),
+		done:  make(chan struct{}),
+	}
+	b.mu.Lock()
+	b.peers[peer] = struct{}{}
+	b.mu.Unlock()
+	go peer.writeMessages(b.lc, b.config.WriteTimeout)
+	return peer
+}
+
+// servePeer reads the messages from the peer until it is disconnected. The hub relays the messages to the other
+// processes.
+func (b *SocketBus) servePeer(peer *socketPeer, isHub bool) {
+	stop := context.AfterFunc(b.ctx, func() {
+		_ = peer.conn.Close()
+	})
+	defer func() {
+		stop()
+		_ = peer.conn.Close()
+		b.mu.Lock()
+		delete(b.peers, peer)
+		b.mu.Unlock()
+		close(peer.done)
+	}()
+
+	decoder := json.NewDecoder(peer.conn)
+	for {
+		var msg BusMessage
+		if err := decoder.Decode(&msg); err != nil {
+			b.lc.Debugf("sse bus: Disconnected from %s: %v", peer.conn.RemoteAddr(), err)
+			return
+		}
+		if isHub {
+			if err := b.broadcast(msg, peer); err != nil {
+				b.lc.Warnf("sse bus: Failed to relay the message of topic '%s': %v", msg.Topic, err)
+			}
+		}
+		b.handlers.dispatch(msg)
+	}
+}
+
+// broadcast queues the message for sending to the connected processes except the sender
+func (b *SocketBus) broadcast(msg BusMessage, sender *socketPeer) error {
+	b.mu.Lock()
+	peers := make([]*socketPeer, 0, len(b.peers))
+	for peer := range b.peers {
+		if peer != sender {
+			peers = append(peers, peer)
+		}
+	}
+	b.mu.Unlock()
+
+	var errs []error
+	for _, peer := range peers {
+		if !peer.enqueue(msg) {
+			// The peer is disconnected once its connection is closed, and reconnects by itself
+			_ = peer.conn.Close()
+			errs = append(errs, fmt.Errorf("send queue of %s is full", peer.conn.RemoteAddr()))
+		}
+	}
+	return goErr.Join(errs...)
+}
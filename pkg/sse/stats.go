@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+// SubscriberStats holds the statistics of a subscriber of a topic.
+type SubscriberStats struct {
+	// Pending is the number of the messages waiting in the channel of the subscriber
+	Pending        int            `json:"pending"`
+	BufferSize     int            `json:"bufferSize"`
+	OverflowPolicy OverflowPolicy `json:"overflowPolicy,omitempty"`
+	Dropped        uint64         `json:"dropped"`
+}
+
+// TopicStats holds the statistics of the broadcaster of a topic.
+type TopicStats struct {
+	Topic string `json:"topic"`
+	// Polling indicates whether the polling service of the topic is running
+	Polling bool `json:"polling"`
+	// Published is the number of the published messages including the unchanged ones, which are not sent
+	Published uint64 `json:"published"`
+	// LastPublished is the time of the last published message, zero if none
+	LastPublished time.Time `json:"lastPublished"`
+	// LastEventID is the ID of the last event sent to the subscribers, i.e. the number of the changes
+	LastEventID uint64 `json:"lastEventId"`
+	// Dropped is the number of the messages dropped for all the subscribers, including the removed ones
+	Dropped     uint64            `json:"dropped"`
+	Subscribers []SubscriberStats `json:"subscribers"`
+}
+
+// StatsResponse is the response of StatsHandler.
+type StatsResponse struct {
+	rest.BaseResponse `json:",inline"`
+	Topics            []TopicStats `json:"topics"`
+}
+
+// Stats returns the statistics of the broadcaster. The topic is left empty, which is only known to the Manager.
+func (b *Broadcaster) Stats() TopicStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := TopicStats{
+		Polling:       b.polling.Load(),
+		Published:     b.published,
+		LastPublished: b.lastPublished,
+		Dropped:       b.dropped,
+		Subscribers:   make([]SubscriberStats, 0, len(b.subscribers)),
+	}
+	if b.lastEvent != nil {
+		stats.LastEventID = b.lastEvent.ID
+	}
+	for _, s := range b.subscribers {
+		stats.Subscribers = append(stats.Subscribers, SubscriberStats{
+			Pending:        len(s.ch),
+			BufferSize:     cap(s.ch),
+			OverflowPolicy: s.overflowPolicy,
+			Dropped:        s.dropped,
+		})
+	}
+	return stats
+}
+
+// Stats returns the statistics of the active topics, sorted by topic.
+func (m *Manager) Stats() []TopicStats {
+	m.mu.RLock()
+	topics := make(map[string]*Broadcaster, len(m.broadcasters))
+	for topic, b := range m.broadcasters {
+		topics[topic] = b
+	}
+	m.mu.RUnlock()
+
+	stats := make([]TopicStats, 0, len(topics))
+	for topic, b := range topics {
+		s := b.Stats()
+		s.Topic = topic
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Topic < stats[j].Topic
+	})
+	return stats
+}
+
+// StatsHandler creates a handler responding the statistics of the active topics of the Manager as JSON, which can be
+// added to a route for diagnosing the SSE connections and the polling of the topics.
+func StatsHandler(m *Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, StatsResponse{
+			BaseResponse: rest.NewBaseResponse(common.ApiVersion, "", "", http.StatusOK),
+			Topics:       m.Stats(),
+		})
+	}
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+func TestManagerStats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+
+	polled, _ := m.CreateOrGetBroadcaster("polled")
+	polled.SetPollingService(&mockPollingService{})
+	polled.StartPolling()
+	ch := polled.Subscribe(WithSubscriberBuffer(1, OverflowDropNewest))
+	defer polled.Unsubscribe(ch)
+	polled.Publish("a")
+	polled.Publish("a")
+	polled.Publish("b")
+
+	idle, _ := m.CreateOrGetBroadcaster("idle")
+	idleCh := idle.Subscribe()
+	defer idle.Unsubscribe(idleCh)
+
+	stats := m.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "idle", stats[0].Topic)
+	assert.False(t, stats[0].Polling)
+	assert.True(t, stats[0].LastPublished.IsZero())
+	assert.Equal(t, []SubscriberStats{{Pending: 0, BufferSize: DefaultSubscriberBufferSize}}, stats[0].Subscribers)
+
+	assert.Equal(t, "polled", stats[1].Topic)
+	assert.True(t, stats[1].Polling)
+	assert.Equal(t, uint64(3), stats[1].Published)
+	assert.False(t, stats[1].LastPublished.IsZero())
+	assert.Equal(t, uint64(2), stats[1].LastEventID)
+	assert.Equal(t, uint64(1), stats[1].Dropped)
+	assert.Equal(t, []SubscriberStats{{Pending: 1, BufferSize: 1, OverflowPolicy: OverflowDropNewest, Dropped: 1}}, stats[1].Subscribers)
+
+	require.NoError(t, polled.StopPolling())
+	assert.False(t, polled.Stats().Polling)
+}
+
+func TestStatsHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	b, _ := m.CreateOrGetBroadcaster(testTopic)
+	ch := b.Subscribe()
+	defer b.Unsubscribe(ch)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/sse/stats", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, StatsHandler(m)(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp StatsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, resp.Topics, 1)
+	assert.Equal(t, testTopic, resp.Topics[0].Topic)
+	assert.Len(t, resp.Topics[0].Subscribers, 1)
+}
@@ -0,0 +1,127 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Content encodings supported by the compressed SSE streams
+const (
+	contentEncodingGzip    = "gzip"
+	contentEncodingDeflate = "deflate"
+)
+
+// compressor is the writer compressing the SSE stream, i.e. gzip.Writer or zlib.Writer
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// eventStream writes the SSE stream to a client, which is compressed with the content encoding negotiated with the
+// client if the compression is enabled.
+type eventStream struct {
+	w          io.Writer
+	response   *echo.Response
+	compressor compressor
+}
+
+// newEventStream creates the eventStream of the response, and sets the Content-Encoding header if the stream is
+// compressed. It must be called before writing the response.
+func newEventStream(c echo.Context, compression bool) *eventStream {
+	s := &eventStream{w: c.Response().Writer, response: c.Response()}
+	if !compression {
+		return s
+	}
+
+	header := c.Response().Header()
+	header.Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+	// The streams are compressed at the best speed, as each event is flushed on its own
+	switch negotiateContentEncoding(c.Request().Header.Get(echo.HeaderAcceptEncoding)) {
+	case contentEncodingGzip:
+		s.compressor, _ = gzip.NewWriterLevel(s.w, flate.BestSpeed)
+		header.Set(echo.HeaderContentEncoding, contentEncodingGzip)
+	case contentEncodingDeflate:
+		s.compressor, _ = zlib.NewWriterLevel(s.w, flate.BestSpeed)
+		header.Set(echo.HeaderContentEncoding, contentEncodingDeflate)
+	default:
+		return s
+	}
+	header.Del(echo.HeaderContentLength)
+	s.w = s.compressor
+	return s
+}
+
+func (s *eventStream) Write(p []byte) (int, error) {
+	return s.w.Write(p)
+}
+
+// flush sends the data written so far to the client, so that the compressed events arrive promptly
+func (s *eventStream) flush() error {
+	if s.compressor != nil {
+		if err := s.compressor.Flush(); err != nil {
+			return err
+		}
+	}
+	if _, ok := s.response.Writer.(http.Flusher); ok {
+		s.response.Flush()
+	}
+	return nil
+}
+
+// close finishes the compressed stream, which is a no-op if the stream isn't compressed
+func (s *eventStream) close() error {
+	if s.compressor == nil {
+		return nil
+	}
+	return s.compressor.Close()
+}
+
+// negotiateContentEncoding returns the content encoding of the stream accepted by the client, preferring gzip over
+// deflate, or an empty string if the client accepts neither of them.
+func negotiateContentEncoding(acceptEncoding string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		accepted[coding] = qualityOf(params) > 0
+	}
+
+	for _, coding := range []string{contentEncodingGzip, contentEncodingDeflate} {
+		if ok, listed := accepted[coding]; listed {
+			if ok {
+				return coding
+			}
+			continue
+		}
+		if accepted["*"] {
+			return coding
+		}
+	}
+	return ""
+}
+
+// qualityOf returns the q value of the parameters of a coding in the Accept-Encoding header, which is 1 if absent
+func qualityOf(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if strings.EqualFold(name, "q") {
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return 0
+			}
+			return q
+		}
+	}
+	return 1
+}
@@ -0,0 +1,96 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+)
+
+func TestNegotiateContentEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		expected       string
+	}{
+		{"none", "", ""},
+		{"identity", "identity", ""},
+		{"gzip", "gzip", contentEncodingGzip},
+		{"deflate", "deflate", contentEncodingDeflate},
+		{"gzip preferred", "deflate, gzip, br", contentEncodingGzip},
+		{"gzip refused", "gzip;q=0, deflate;q=0.5", contentEncodingDeflate},
+		{"case insensitive", "GZIP; Q=0.8", contentEncodingGzip},
+		{"wildcard", "*", contentEncodingGzip},
+		{"wildcard except gzip", "gzip;q=0, *;q=0.1", contentEncodingDeflate},
+		{"invalid quality", "gzip;q=high", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateContentEncoding(tt.acceptEncoding))
+		})
+	}
+}
+
+func TestHandlerCompression(t *testing.T) {
+	tests := []struct {
+		encoding  string
+		newReader func(r io.Reader) (io.Reader, error)
+	}{
+		{contentEncodingGzip, func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{contentEncodingDeflate, func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+			server := newTestServer(t, m, WithCustomTopic(testTopic), WithCompression())
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/sse", nil)
+			require.NoError(t, err)
+			req.Header.Set(echo.HeaderAcceptEncoding, tt.encoding)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = resp.Body.Close() })
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.encoding, resp.Header.Get(echo.HeaderContentEncoding))
+
+			// The events are decompressed as they arrive without waiting for the end of the stream
+			reader, err := tt.newReader(resp.Body)
+			require.NoError(t, err)
+			stream := &sseStream{resp: resp, scanner: bufio.NewScanner(reader)}
+			b := waitForSubscribers(t, m, testTopic, 1)
+			b.Publish(map[string]int{"count": 1})
+			assert.Equal(t, map[string]string{"id": "1", "data": `{"count":1}`}, stream.next(t))
+			b.Publish(map[string]int{"count": 2})
+			assert.Equal(t, map[string]string{"id": "2", "data": `{"count":2}`}, stream.next(t))
+		})
+	}
+}
+
+func TestHandlerCompressionNotAccepted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	server := newTestServer(t, m, WithCustomTopic(testTopic), WithCompression())
+
+	stream := openStream(t, server.URL+"/sse", http.Header{echo.HeaderAcceptEncoding: []string{"br"}})
+	assert.Empty(t, stream.resp.Header.Get(echo.HeaderContentEncoding))
+	b := waitForSubscribers(t, m, testTopic, 1)
+	b.Publish("plain")
+	assert.Equal(t, map[string]string{"id": "1", "data": `"plain"`}, stream.next(t))
+}
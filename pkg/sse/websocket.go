@@ -0,0 +1,405 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	goErr "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/common"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+// Actions of the requests from the WebSocket clients
+const (
+	WebSocketActionSubscribe   = "subscribe"
+	WebSocketActionUnsubscribe = "unsubscribe"
+)
+
+// maxWebSocketRequestSize is the maximum size of a request from the WebSocket clients
+const maxWebSocketRequestSize = 64 * 1024
+
+// DefaultMaxWebSocketSubscriptions is the default maximum number of the topics subscribed over a WebSocket connection
+const DefaultMaxWebSocketSubscriptions = 64
+
+// AllowAllOrigins is the origin passed to WithAllowedOrigins to accept the WebSocket connections from any origin
+const AllowAllOrigins = "*"
+
+// WebSocketRequest is a request from a WebSocket client to subscribe or unsubscribe a topic.
+type WebSocketRequest struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+	// LastEventID is the ID of the last event of the topic received by a reconnecting client, so that the buffered
+	// events after it are replayed to the client.
+	LastEventID uint64 `json:"lastEventId,omitempty"`
+}
+
+// WebSocketMessage is a message sent to a WebSocket client, which carries an event of a subscribed topic or the error
+// response of a request under the EventTypeError event type.
+type WebSocketMessage struct {
+	Topic string          `json:"topic"`
+	ID    uint64          `json:"id,omitempty"`
+	Event string          `json:"event,omitempty"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// pingCodec sends a ping frame, which is answered with a pong frame by the client
+var pingCodec = websocket.Codec{
+	Marshal: func(any) ([]byte, byte, error) {
+		return nil, websocket.PingFrame, nil
+	},
+}
+
+// WebSocketHandler creates a WebSocket handler which serves the topics of the Manager alongside the SSE handlers, so
+// that a topic can serve the SSE and WebSocket clients simultaneously with the same broadcaster and polling. A client
+// can subscribe and unsubscribe multiple topics over a single socket with WebSocketRequest, and receives the events of
+// the subscribed topics as WebSocketMessage. The socket is pinged at the heartbeat interval of the Manager, and is
+// closed if nothing, including the pongs, is received from the client within twice the heartbeat interval.
+//
+// The options are shared with Handler except WithCustomTopic, WithRetryInterval, WithCompression and
+// WithMaxEventSize, and WithPollingServiceFactory should be used instead of WithPollingService to poll the subscribed
+// topics. The Origin header of the browsers must match the host of the request unless the origin is allowed with
+// WithAllowedOrigins, as the browsers send the cookies of the service along with the cross-site requests. The
+// non-browser clients, which don't send the Origin header, are accepted. The topic of each subscribe request is
+// authorized with WithAuthorizer, and the events of a narrowed topic are still sent under the requested topic. The
+// number of the topics subscribed over a connection is capped with WithMaxSubscriptionsPerConnection.
+func WebSocketHandler(m *Manager, opts ...HandlerOption) echo.HandlerFunc {
+	// Apply options to the HandlerConfig if provided
+	config := &HandlerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return func(c echo.Context) error {
+		release, err := config.admitConnection(c, m)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		server := websocket.Server{
+			Handshake: config.checkOrigin,
+			Handler: func(ws *websocket.Conn) {
+				ws.MaxPayloadBytes = maxWebSocketRequestSize
+				newWebSocketSession(c, m, config, ws).serve()
+			},
+		}
+		w := &readDeadlineWriter{ResponseWriter: c.Response(), timeout: 2 * heartbeatIntervalOf(m)}
+		server.ServeHTTP(w, c.Request())
+		return nil
+	}
+}
+
+// checkOrigin accepts the WebSocket handshake if the request doesn't carry the Origin header, or the origin matches
+// the host of the request or is allowed by WithAllowedOrigins.
+func (config *HandlerConfig) checkOrigin(wsConfig *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(wsConfig, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil || strings.EqualFold(origin.Host, req.Host) {
+		return nil
+	}
+	for _, allowed := range config.AllowedOrigins {
+		if allowed == AllowAllOrigins || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin.Scheme+"://"+origin.Host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("origin '%s' is not allowed", origin)
+}
+
+// heartbeatIntervalOf returns the heartbeat interval of the Manager, or the default one if it is unset or invalid
+func heartbeatIntervalOf(m *Manager) time.Duration {
+	if m.heartbeatInterval <= 0 {
+		return defaultHeartbeatInterval
+	}
+	return m.heartbeatInterval
+}
+
+// readDeadlineWriter hijacks the connection of the WebSocket handshake as a readDeadlineConn
+type readDeadlineWriter struct {
+	http.ResponseWriter
+	timeout time.Duration
+}
+
+func (w *readDeadlineWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	dc := &readDeadlineConn{Conn: conn, timeout: w.timeout}
+	if err := dc.SetReadDeadline(time.Now().Add(dc.timeout)); err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	// The data buffered by the HTTP server is read ahead of the connection
+	buffered, _ := rw.Reader.Peek(rw.Reader.Buffered())
+	reader := bufio.NewReader(io.MultiReader(bytes.NewReader(bytes.Clone(buffered)), dc))
+	return dc, bufio.NewReadWriter(reader, rw.Writer), nil
+}
+
+// readDeadlineConn extends the read deadline of the connection whenever data is received, i.e. on each frame or pong,
+// so that a client which neither sends frames nor answers the pings is disconnected once the deadline passes.
+type readDeadlineConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *readDeadlineConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		if deadlineErr := c.Conn.SetReadDeadline(time.Now().Add(c.timeout)); deadlineErr != nil && err == nil {
+			err = deadlineErr
+		}
+	}
+	return n, err
+}
+
+type webSocketSubscription struct {
+	b  *Broadcaster
+	ch SubscriberCh
+	// unsubscribed is set before the subscription is cancelled by the client or the session, so that it can be
+	// distinguished from the disconnection by the overflow policy
+	unsubscribed atomic.Bool
+}
+
+// webSocketSession serves a WebSocket connection. The events of each subscription are forwarded by a goroutine to
+// the serving loop, which is the only writer of the connection.
+type webSocketSession struct {
+	c      echo.Context
+	m      *Manager
+	config *HandlerConfig
+	ws     *websocket.Conn
+	lc     log.Logger
+
+	out chan WebSocketMessage
+	// done is closed once the session is closed
+	done chan struct{}
+	// disconnected is closed if a subscription is disconnected by the overflow policy
+	disconnected     chan struct{}
+	disconnectedOnce sync.Once
+
+	mu            sync.Mutex
+	subscriptions map[string]*webSocketSubscription
+	closed        bool
+}
+
+func newWebSocketSession(c echo.Context, m *Manager, config *HandlerConfig, ws *websocket.Conn) *webSocketSession {
+	return &webSocketSession{
+		c:             c,
+		m:             m,
+		config:        config,
+		ws:            ws,
+		lc:            m.lc,
+		out:           make(chan WebSocketMessage),
+		done:          make(chan struct{}),
+		disconnected:  make(chan struct{}),
+		subscriptions: make(map[string]*webSocketSubscription),
+	}
+}
+
+func (s *webSocketSession) serve() {
+	defer s.close()
+
+	requestsDone := make(chan struct{})
+	go func() {
+		defer close(requestsDone)
+		s.readRequests()
+	}()
+
+	heartbeatInterval := heartbeatIntervalOf(s.m)
+	heartbeatTicker := time.NewTicker(heartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	for {
+		select {
+		case msg := <-s.out:
+			if err := s.send(websocket.JSON, msg, heartbeatInterval); err != nil {
+				s.lc.Errorf("sse websocket: failed to write message: %v", err)
+				return
+			}
+
+		case <-heartbeatTicker.C:
+			if err := s.send(pingCodec, nil, heartbeatInterval); err != nil {
+				s.lc.Warnf("sse websocket: heartbeat write failed: %v", err)
+				return
+			}
+
+		case <-s.disconnected:
+			// Close the socket so that the client reconnects and resubscribes with the last event IDs
+			s.lc.Debug("sse websocket: Subscriber is too slow, closing the WebSocket connection")
+			return
+
+		case <-requestsDone:
+			s.lc.Debug("sse websocket: Connection closed by the client")
+			return
+
+		case <-s.m.ctx.Done():
+			s.lc.Info("sse websocket: Service shutting down, closing the WebSocket connection")
+			return
+		}
+	}
+}
+
+// send writes a message with a write deadline, so that a broken or extremely slow connection is detected
+func (s *webSocketSession) send(codec websocket.Codec, msg any, timeout time.Duration) error {
+	if err := s.ws.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	return codec.Send(s.ws, msg)
+}
+
+func (s *webSocketSession) readRequests() {
+	for {
+		var req WebSocketRequest
+		if err := websocket.JSON.Receive(s.ws, &req); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if goErr.As(err, &syntaxErr) || goErr.As(err, &typeErr) || goErr.Is(err, websocket.ErrFrameTooLarge) {
+				s.sendError(req.Topic, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+				continue
+			}
+			return
+		}
+
+		switch req.Action {
+		case WebSocketActionSubscribe:
+			s.subscribe(req)
+		case WebSocketActionUnsubscribe:
+			s.unsubscribe(req.Topic)
+		default:
+			s.sendError(req.Topic, http.StatusBadRequest, fmt.Sprintf("unknown action '%s'", req.Action))
+		}
+	}
+}
+
+func (s *webSocketSession) subscribe(req WebSocketRequest) {
+	if req.Topic == "" {
+		s.sendError(req.Topic, http.StatusBadRequest, "topic is required")
+		return
+	}
+
+	s.mu.Lock()
+	_, subscribed := s.subscriptions[req.Topic]
+	full := !subscribed && len(s.subscriptions) >= s.config.maxSubscriptionsPerConnection()
+	s.mu.Unlock()
+	if full {
+		s.sendError(req.Topic, http.StatusTooManyRequests,
+			fmt.Sprintf("max %d subscriptions per connection exceeded", s.config.maxSubscriptionsPerConnection()))
+		return
+	}
+
+	topic, err := s.config.authorize(s.c, req.Topic)
+	if err != nil {
+		s.lc.Debugf("sse websocket: Subscription to topic '%s' is rejected: %v", req.Topic, err)
+		s.sendError(req.Topic, http.StatusForbidden, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscriptions[req.Topic]; ok || s.closed {
+		return
+	}
+
+	s.lc.Debugf("sse websocket: Subscribing topic '%s'", topic)
+	b := s.config.setupBroadcaster(s.m, topic)
+	sub := &webSocketSubscription{b: b}
+	sub.ch = b.Subscribe(s.config.subscribeOptions(s.c, req.LastEventID)...)
+	s.subscriptions[req.Topic] = sub
+	go s.forward(req.Topic, sub)
+}
+
+// maxSubscriptionsPerConnection returns the maximum number of the topics subscribed over a WebSocket connection
+func (config *HandlerConfig) maxSubscriptionsPerConnection() int {
+	if config.MaxSubscriptionsPerConnection <= 0 {
+		return DefaultMaxWebSocketSubscriptions
+	}
+	return config.MaxSubscriptionsPerConnection
+}
+
+func (s *webSocketSession) unsubscribe(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sub, ok := s.subscriptions[topic]; ok {
+		s.lc.Debugf("sse websocket: Unsubscribing topic '%s'", topic)
+		delete(s.subscriptions, topic)
+		sub.unsubscribed.Store(true)
+		sub.b.Unsubscribe(sub.ch)
+	}
+}
+
+// forward passes the events of the subscription to the serving loop until the subscription is cancelled
+func (s *webSocketSession) forward(topic string, sub *webSocketSubscription) {
+	// The delta updates are computed against the payload written to this client
+	delta := &deltaEncoder{mode: s.config.DeltaMode}
+	for msg := range sub.ch {
+		event := msg.(Event)
+		data, err := json.Marshal(event.Data)
+		if err != nil {
+			s.lc.Errorf("sse websocket: failed to serialize message: %v", err)
+			continue
+		}
+		if event.Type == "" {
+			event.Type, data = delta.encode(data)
+		}
+
+		select {
+		case s.out <- WebSocketMessage{Topic: topic, ID: event.ID, Event: event.Type, Data: data}:
+		case <-s.done:
+			return
+		}
+	}
+
+	if !sub.unsubscribed.Load() {
+		s.disconnectedOnce.Do(func() {
+			close(s.disconnected)
+		})
+	}
+}
+
+func (s *webSocketSession) sendError(topic string, statusCode int, message string) {
+	data, err := json.Marshal(rest.NewBaseResponse(common.ApiVersion, "", message, statusCode))
+	if err != nil {
+		s.lc.Errorf("sse websocket: failed to serialize error response: %v", err)
+		return
+	}
+	select {
+	case s.out <- WebSocketMessage{Topic: topic, Event: EventTypeError, Data: data}:
+	case <-s.done:
+	}
+}
+
+// close cancels all the subscriptions and closes the connection
+func (s *webSocketSession) close() {
+	s.mu.Lock()
+	s.closed = true
+	for topic, sub := range s.subscriptions {
+		delete(s.subscriptions, topic)
+		sub.unsubscribed.Store(true)
+		sub.b.Unsubscribe(sub.ch)
+	}
+	s.mu.Unlock()
+
+	close(s.done)
+	if err := s.ws.Close(); err != nil {
+		s.lc.Debugf("sse websocket: failed to close the connection: %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/log"
+	"github.com/IOTechSystems/go-mod-edge-utils/v2/pkg/rest"
+)
+
+func dialWebSocket(t *testing.T, m *Manager, opts ...HandlerOption) *websocket.Conn {
+	e := echo.New()
+	e.GET("/ws", WebSocketHandler(m, opts...))
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", "", server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+	return ws
+}
+
+func receiveMessage(t *testing.T, ws *websocket.Conn) WebSocketMessage {
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg WebSocketMessage
+	require.NoError(t, websocket.JSON.Receive(ws, &msg))
+	return msg
+}
+
+func TestWebSocketHandlerMultipleTopics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), 50*time.Millisecond)
+	ws := dialWebSocket(t, m)
+
+	// The topic is shared with the SSE clients
+	server := newTestServer(t, m, WithCustomTopic("a"))
+	stream := openStream(t, server.URL+"/sse", nil)
+
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "a"}))
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "b"}))
+	a := waitForSubscribers(t, m, "a", 2)
+	b := waitForSubscribers(t, m, "b", 1)
+
+	a.Publish("a1")
+	assert.Equal(t, WebSocketMessage{Topic: "a", ID: 1, Data: []byte(`"a1"`)}, receiveMessage(t, ws))
+	assert.Equal(t, map[string]string{"id": "1", "data": `"a1"`}, stream.next(t))
+	b.Publish("b1")
+	assert.Equal(t, WebSocketMessage{Topic: "b", ID: 1, Data: []byte(`"b1"`)}, receiveMessage(t, ws))
+
+	// The socket is kept alive past the read timeout while the client answers the pings, which the client does while
+	// receiving
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+	var msg WebSocketMessage
+	require.ErrorIs(t, websocket.JSON.Receive(ws, &msg), os.ErrDeadlineExceeded)
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionUnsubscribe, Topic: "b"}))
+	require.Eventually(t, func() bool {
+		_, ok := m.GetBroadcaster("b")
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+	a.Publish("a2")
+	assert.Equal(t, WebSocketMessage{Topic: "a", ID: 2, Data: []byte(`"a2"`)}, receiveMessage(t, ws))
+
+	// The subscriptions are cancelled once the socket is closed
+	require.NoError(t, ws.Close())
+	waitForSubscribers(t, m, "a", 1)
+}
+
+func TestWebSocketHandlerPollingAndReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	published := make(chan Publisher, 1)
+	ws := dialWebSocket(t, m, WithPollingServiceFactory(func(topic string) PollingService {
+		return &publisherCapture{published: published}
+	}))
+
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "polled"}))
+	var pub Publisher
+	select {
+	case pub = <-published:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "the polling service of the topic is not started")
+	}
+	pub.Publish("p1")
+	pub.Publish("p2")
+	assert.Equal(t, uint64(1), receiveMessage(t, ws).ID)
+	assert.Equal(t, uint64(2), receiveMessage(t, ws).ID)
+
+	// A reconnecting client is replayed the events after its last event ID
+	replayed := dialWebSocket(t, m)
+	require.NoError(t, websocket.JSON.Send(replayed, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "polled", LastEventID: 1}))
+	assert.Equal(t, WebSocketMessage{Topic: "polled", ID: 2, Data: []byte(`"p2"`)}, receiveMessage(t, replayed))
+}
+
+func TestWebSocketHandlerInvalidRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	ws := dialWebSocket(t, m)
+
+	require.NoError(t, websocket.Message.Send(ws, "not json"))
+	msg := receiveMessage(t, ws)
+	assert.Equal(t, EventTypeError, msg.Event)
+
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: "publish", Topic: "a"}))
+	msg = receiveMessage(t, ws)
+	assert.Equal(t, "a", msg.Topic)
+	assert.Equal(t, EventTypeError, msg.Event)
+	var resp rest.BaseResponse
+	require.NoError(t, json.Unmarshal(msg.Data, &resp))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestWebSocketHandlerReadTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), 50*time.Millisecond)
+	ws := dialWebSocket(t, m)
+
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "a"}))
+	waitForSubscribers(t, m, "a", 1)
+
+	// The client neither sends frames nor answers the pings, so that the connection is closed
+	require.Eventually(t, func() bool {
+		_, ok := m.GetBroadcaster("a")
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestWebSocketHandlerMaxSubscriptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+	ws := dialWebSocket(t, m, WithMaxSubscriptionsPerConnection(1))
+
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "a"}))
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "b"}))
+	msg := receiveMessage(t, ws)
+	assert.Equal(t, "b", msg.Topic)
+	assert.Equal(t, EventTypeError, msg.Event)
+	var resp rest.BaseResponse
+	require.NoError(t, json.Unmarshal(msg.Data, &resp))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// Subscribing another topic is accepted once a topic is unsubscribed
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionUnsubscribe, Topic: "a"}))
+	require.NoError(t, websocket.JSON.Send(ws, WebSocketRequest{Action: WebSocketActionSubscribe, Topic: "b"}))
+	b := waitForSubscribers(t, m, "b", 1)
+	b.Publish("b1")
+	assert.Equal(t, WebSocketMessage{Topic: "b", ID: 1, Data: []byte(`"b1"`)}, receiveMessage(t, ws))
+}
+
+func TestWebSocketHandlerOrigin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, log.NewNopeLogger(), time.Minute)
+
+	tests := []struct {
+		name     string
+		origin   string
+		opts     []HandlerOption
+		accepted bool
+	}{
+		{"same origin", "", nil, true},
+		{"cross origin", "https://evil.example.com", nil, false},
+		{"allowed cross origin", "https://console.example.com", []HandlerOption{WithAllowedOrigins("https://console.example.com")}, true},
+		{"all origins allowed", "https://evil.example.com", []HandlerOption{WithAllowedOrigins(AllowAllOrigins)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.GET("/ws", WebSocketHandler(m, tt.opts...))
+			server := httptest.NewServer(e)
+			defer server.Close()
+
+			origin := tt.origin
+			if origin == "" {
+				origin = server.URL
+			}
+			ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", "", origin)
+			if !tt.accepted {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			_ = ws.Close()
+		})
+	}
+}
+
+// publisherCapture is a PollingService passing the Publisher it is started with
+type publisherCapture struct {
+	published chan Publisher
+}
+
+func (p *publisherCapture) Start(publisher Publisher) {
+	p.published <- publisher
+}
+
+func (p *publisherCapture) Stop() error {
+	return nil
+}